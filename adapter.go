@@ -0,0 +1,48 @@
+package lit
+
+import "net/http"
+
+// WrapHandler adapts h to a HandlerFunc, typically to end a chain.
+func WrapHandler(h http.Handler) HandlerFunc {
+	return func(c *Context) error {
+		h.ServeHTTP(c.Res, c.Req)
+		return nil
+	}
+}
+
+// WrapMiddleware adapts net/http middleware to a HandlerFunc. When mw calls
+// the next handler the rest of the chain runs with the request and response
+// writer it passes along, and its error is returned to the previous handlers.
+func WrapMiddleware(mw func(http.Handler) http.Handler) HandlerFunc {
+	return func(c *Context) error {
+		var err error
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, req, header := c.Res, c.Req, c.Header
+			if w != http.ResponseWriter(res) {
+				// writes go through the writer of mw, which in turn writes
+				// to res, so both keep track of the response
+				c.Res = &Response{ResponseWriter: w, discard: res.discard, ctx: c}
+				c.Header = w.Header()
+			}
+			c.Req = r
+
+			err = c.Next()
+			c.Res, c.Req, c.Header = res, req, header
+		})
+
+		mw(next).ServeHTTP(c.Res, c.Req)
+		return err
+	}
+}
+
+// Middleware exports the app middleware as net/http middleware. Requests run
+// the handlers registered with Use before reaching next, and errors they
+// return are passed to the ErrorHandler.
+func (a *App) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		h := WrapHandler(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			a.serve(w, r, a.withMiddleware(h))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package lit_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+// upper is a response writer upper casing the body.
+type upper struct {
+	http.ResponseWriter
+}
+
+func (u upper) Write(b []byte) (int, error) {
+	return u.ResponseWriter.Write(bytes.ToUpper(b))
+}
+
+func TestWrapMiddleware(t *testing.T) {
+	app := lit.New()
+
+	var status int
+	var size int64
+	app.Use(func(c *lit.Context) error {
+		err := c.Next()
+		status, size = c.Res.Status, c.Res.Size
+		return err
+	})
+
+	app.Use(lit.WrapMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Block") != "" {
+				http.Error(w, "blocked", http.StatusForbidden)
+				return
+			}
+			w.Header().Set("X-Std", "yes")
+			next.ServeHTTP(upper{w}, r)
+		})
+	}))
+
+	app.GET("/", func(c *lit.Context) error {
+		return c.Text("hello", http.StatusAccepted)
+	})
+
+	app.GET("/err", func(c *lit.Context) error {
+		return lit.ErrTeapot
+	})
+
+	app.GET("/std", lit.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("std"))
+	})))
+
+	t.Run("wrapped writer", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/", nil, false)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "HELLO", w.Body.String())
+		assert.Equal(t, "yes", w.Header().Get("X-Std"))
+		assert.Equal(t, http.StatusAccepted, status)
+		assert.Equal(t, int64(5), size)
+	})
+
+	t.Run("error propagation", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/err", nil, false)
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Block", "1")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("wrap handler", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/std", nil, false)
+		assert.Equal(t, "STD", w.Body.String())
+	})
+}
+
+func TestAppMiddleware(t *testing.T) {
+	app := lit.New()
+	app.Use(trace("lit"))
+	app.Use(func(c *lit.Context) error {
+		if c.Req.Header.Get(lit.HeaderAuthorization) == "" {
+			return lit.ErrUnauthorized
+		}
+		return c.Next()
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("std"))
+	})
+	h := app.Middleware()(mux)
+
+	t.Run("passes through", func(t *testing.T) {
+		w := makeRequest(h, "GET", "/", nil, true)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "std", w.Body.String())
+		assert.Equal(t, "lit", w.Header().Get("X-Trace"))
+	})
+
+	t.Run("error handler", func(t *testing.T) {
+		w := makeRequest(h, "GET", "/", nil, false)
+		body, _ := decode[lit.Map](w.Body)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "Unauthorized", body["message"])
+	})
+}
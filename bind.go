@@ -0,0 +1,234 @@
+package lit
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// MaxMultipartMemory is the memory used to parse multipart forms when
+// binding, the rest of the parts is stored in temporary files.
+var MaxMultipartMemory int64 = 32 << 20
+
+// FieldError describes a request value that could not be bound to a field.
+type FieldError struct {
+	Field   string `json:"field"`  // name of the value in the request
+	Source  string `json:"source"` // param, query, header, form or body
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %q: %s", e.Source, e.Field, e.Message)
+}
+
+// sources are the struct tags naming where a field is bound from.
+var sources = []string{"param", "query", "header", "form"}
+
+// Bind populates v, a pointer, from the request. The body is decoded
+// according to its Content-Type: JSON, XML or a form. Then the fields of a
+// struct are set from the path values, query, headers and form values named
+// by their tags:
+//
+//	type Params struct {
+//		ID     int       `param:"id"`
+//		Page   int       `query:"page"`
+//		Tags   []string  `query:"tag"`
+//		Tenant string    `header:"X-Tenant"`
+//		Name   string    `form:"name"`
+//		Avatar *multipart.FileHeader `form:"avatar"`
+//	}
+//
+// A body of an unsupported type results in ErrUnsupportedMediaType and
+// values which do not parse, including JSON body values of the wrong type,
+// in ErrBadRequest listing a FieldError for each.
+// When the app has a Validator, v is validated once bound, see Validate.
+func (c *Context) Bind(v any) error {
+	if err := c.bindBody(v); err != nil {
+		return err
+	}
+	if err := c.bindValues(v); err != nil {
+		return err
+	}
+	if c.app.Validator != nil {
+		return c.Validate(v)
+	}
+	return nil
+}
+
+// Bind binds a new T from the request, see Context.Bind.
+func Bind[T any](c *Context) (T, error) {
+	var v T
+	err := c.Bind(&v)
+	return v, err
+}
+
+func (c *Context) bindBody(v any) error {
+	r := c.Req
+	ct := r.Header.Get(HeaderContentType)
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 || ct == "" {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return unsupportedMediaType(ct)
+	}
+
+	switch mediaType {
+	case "application/json":
+		err = json.NewDecoder(r.Body).Decode(v)
+	case "application/xml", "text/xml":
+		err = xml.NewDecoder(r.Body).Decode(v)
+	case MIMEApplicationForm:
+		err = r.ParseForm()
+	case MIMEMultipartForm:
+		err = r.ParseMultipartForm(MaxMultipartMemory)
+	default:
+		return unsupportedMediaType(ct)
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			msg := fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+			errs := []FieldError{{Field: typeErr.Field, Source: "body", Message: msg}}
+			return ErrBadRequest.WithMessage(errs).WithInternal(err)
+		}
+		return ErrBadRequest.WithMessage("invalid " + mediaType + " body").WithInternal(err)
+	}
+	return nil
+}
+
+func unsupportedMediaType(ct string) *HTTPError {
+	return ErrUnsupportedMediaType.WithMessage(fmt.Sprintf("unsupported media type %q", ct))
+}
+
+func (c *Context) bindValues(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs []FieldError
+	c.bindStruct(rv.Elem(), &errs)
+	if len(errs) > 0 {
+		return ErrBadRequest.WithMessage(errs).WithInternal(fieldErrors(errs))
+	}
+	return nil
+}
+
+func (c *Context) bindStruct(v reflect.Value, errs *[]FieldError) {
+	t := v.Type()
+	for i := range t.NumField() {
+		f, fv := t.Field(i), v.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && fv.Kind() == reflect.Struct {
+			c.bindStruct(fv, errs)
+			continue
+		}
+
+		for _, source := range sources {
+			name, ok := f.Tag.Lookup(source)
+			if !ok || name == "-" {
+				continue
+			}
+			if err := c.bindField(fv, source, name); err != nil {
+				*errs = append(*errs, FieldError{Field: name, Source: source, Message: err.Error()})
+			}
+		}
+	}
+}
+
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+func (c *Context) bindField(v reflect.Value, source, name string) error {
+	var values []string
+	switch source {
+	case "param":
+		if s := c.Param(name); s != "" {
+			values = []string{s}
+		}
+	case "query":
+		values = c.Query[name]
+	case "header":
+		values = c.Req.Header.Values(name)
+	case "form":
+		if form := c.Req.MultipartForm; form != nil && len(form.File[name]) > 0 {
+			return setFiles(v, form.File[name])
+		}
+		values = c.Req.PostForm[name]
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	return setValues(v, values)
+}
+
+// setValues sets v from the values, all of them for a slice or the first
+// one otherwise.
+func setValues(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Slice && !implementsText(v) {
+		s := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setText(s.Index(i), value); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+	return setText(v, values[0])
+}
+
+// setText sets v from s, allocating pointers and using the
+// encoding.TextUnmarshaler implementation of v when present.
+func setText(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setText(v.Elem(), s)
+	}
+	if implementsText(v) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if err := setValue(v, s); err != nil {
+		return fmt.Errorf("%q is not a valid %s", s, v.Kind())
+	}
+	return nil
+}
+
+func implementsText(v reflect.Value) bool {
+	return v.CanAddr() && v.Addr().Type().Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
+}
+
+func setFiles(v reflect.Value, files []*multipart.FileHeader) error {
+	switch {
+	case v.Type() == fileHeaderType:
+		v.Set(reflect.ValueOf(files[0]))
+	case v.Kind() == reflect.Slice && v.Type().Elem() == fileHeaderType:
+		v.Set(reflect.ValueOf(files))
+	default:
+		return fmt.Errorf("cannot bind file to %s", v.Type())
+	}
+	return nil
+}
+
+// fieldErrors joins the errors of the fields, kept as the internal error.
+func fieldErrors(errs []FieldError) error {
+	joined := make([]error, len(errs))
+	for i, e := range errs {
+		joined[i] = e
+	}
+	return errors.Join(joined...)
+}
@@ -0,0 +1,142 @@
+package lit_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+type Pagination struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+type UserRequest struct {
+	Pagination
+	ID     int64     `param:"id"`
+	Tags   []string  `query:"tag"`
+	Since  time.Time `query:"since"`
+	Tenant string    `header:"X-Tenant"`
+	Active *bool     `query:"active"`
+	Name   string    `json:"name" xml:"name" form:"name"`
+	Email  string    `json:"email" xml:"email" form:"email"`
+}
+
+func TestBind(t *testing.T) {
+	app := lit.New()
+
+	app.POST("/users/{id}", func(c *lit.Context) error {
+		v, err := lit.Bind[UserRequest](c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(v)
+	})
+
+	app.POST("/upload", func(c *lit.Context) error {
+		var v struct {
+			Title string                `form:"title"`
+			File  *multipart.FileHeader `form:"file"`
+		}
+		if err := c.Bind(&v); err != nil {
+			return err
+		}
+		return c.Text(v.Title + " " + v.File.Filename)
+	})
+
+	send := func(body, ct, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		if ct != "" {
+			req.Header.Set(lit.HeaderContentType, ct)
+		}
+		req.Header.Set("X-Tenant", "acme")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("json and values", func(t *testing.T) {
+		w := send(`{"name":"jo","email":"jo@x.io"}`, lit.MIMEApplicationJSON,
+			"/users/7?page=2&tag=a&tag=b&since=2024-01-02T00:00:00Z&active=true")
+		v, _ := decode[UserRequest](w.Body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int64(7), v.ID)
+		assert.Equal(t, 2, v.Page)
+		assert.Equal(t, []string{"a", "b"}, v.Tags)
+		assert.Equal(t, 2024, v.Since.Year())
+		assert.Equal(t, "acme", v.Tenant)
+		assert.True(t, *v.Active)
+		assert.Equal(t, "jo", v.Name)
+		assert.Equal(t, "jo@x.io", v.Email)
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		w := send(`<user><name>jo</name></user>`, lit.MIMEApplicationXML, "/users/1")
+		v, _ := decode[UserRequest](w.Body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "jo", v.Name)
+	})
+
+	t.Run("form", func(t *testing.T) {
+		w := send("name=jo&email=jo%40x.io", lit.MIMEApplicationForm, "/users/1")
+		v, _ := decode[UserRequest](w.Body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "jo", v.Name)
+		assert.Equal(t, "jo@x.io", v.Email)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		mw.WriteField("title", "avatar")
+		fw, _ := mw.CreateFormFile("file", "me.png")
+		fw.Write([]byte("png"))
+		mw.Close()
+
+		w := send(body.String(), mw.FormDataContentType(), "/upload")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "avatar me.png", w.Body.String())
+	})
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		w := send("name: jo", "application/yaml", "/users/1")
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		w := send(`{"name":`, lit.MIMEApplicationJSON, "/users/1")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("json type error", func(t *testing.T) {
+		w := send(`{"name":42}`, lit.MIMEApplicationJSON, "/users/1")
+		body, _ := decode[map[string][]lit.FieldError](w.Body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []lit.FieldError{
+			{Field: "name", Source: "body", Message: "expected string, got number"},
+		}, body["message"])
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		w := send("", "", "/users/x?page=two&limit=3")
+		body, _ := decode[map[string][]lit.FieldError](w.Body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []lit.FieldError{
+			{Field: "page", Source: "query", Message: `"two" is not a valid int`},
+			{Field: "id", Source: "param", Message: `"x" is not a valid int64`},
+		}, body["message"])
+	})
+}
@@ -25,3 +25,7 @@ func Magenta(v any) string {
 func Cyan(v any) string {
 	return fmt.Sprintf("\033[36m%v\033[0m", v)
 }
+
+func Gray(v any) string {
+	return fmt.Sprintf("\033[90m%v\033[0m", v)
+}
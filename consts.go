@@ -7,6 +7,7 @@ const (
 	MIMEApplicationJavaScript = "application/javascript" + "; " + charsetUTF8
 	MIMEApplicationMsgpack    = "application/msgpack"
 	MIMEApplicationProtobuf   = "application/protobuf"
+	MIMEApplicationProblem    = "application/problem+json"
 	MIMEApplicationXML        = "application/xml" + "; " + charsetUTF8
 	MIMEMultipartForm         = "multipart/form-data"
 	MIMEOctetStream           = "application/octet-stream"
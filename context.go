@@ -1,17 +1,113 @@
 package lit
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 )
 
+// Context holds the state of a request while it runs through the chain of
+// handlers. Contexts are pooled and reused once the request is served, so
+// they must not be retained after the handler returns.
 type Context struct {
-	app    *App          // app instance
-	Req    *http.Request // raw request
-	Res    *Response     // wrapped response
-	Header http.Header   // response header
-	Query  url.Values    // request query
-	Next   func() error  // next handler
+	app      *App          // app instance
+	Req      *http.Request // raw request
+	Res      *Response     // wrapped response
+	Header   http.Header   // response header
+	Query    url.Values    // request query
+	handlers []HandlerFunc // chain of handlers
+	index    int           // position of the running handler
+	called   []bool        // whether the handler at each position called Next
+	route    *Route        // matched route, nil until dispatched or when none matches
+	logger   *slog.Logger  // built by Logger
+}
+
+// reset prepares the context for serving the request with the handlers.
+func (c *Context) reset(w http.ResponseWriter, r *http.Request, handlers []HandlerFunc) {
+	c.Req = r
+	c.Res.reset(w, r)
+	c.Header = nil
+	if w != nil {
+		c.Header = w.Header()
+	}
+	clear(c.Query)
+	if r != nil {
+		parseQuery(c.Query, r.URL.RawQuery)
+	}
+	c.handlers = handlers
+	c.index = -1
+	c.route, c.logger = nil, nil
+	c.called = slices.Grow(c.called[:0], len(handlers))[:len(handlers)]
+	clear(c.called)
+}
+
+// parseQuery is like url.ParseQuery but fills v, skipping malformed pairs.
+func parseQuery(v url.Values, query string) {
+	for query != "" {
+		var pair string
+		pair, query, _ = strings.Cut(query, "&")
+		if pair == "" || strings.Contains(pair, ";") {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			continue
+		}
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			continue
+		}
+		v[key] = append(v[key], value)
+	}
+}
+
+// Next runs the next handler in the chain and returns its error. At the end
+// of the chain there is nothing left to run and Next returns nil.
+//
+// Each handler may call Next at most once. Calling it again would run the
+// rest of the chain twice, so it returns an ErrNextCalledTwice error naming
+// the handler instead, or panics with it when App.Debug is set.
+func (c *Context) Next() error {
+	i := c.index
+	if i >= 0 {
+		if c.called[i] {
+			err := fmt.Errorf("%w: %s", ErrNextCalledTwice, funcName(c.handlers[i]))
+			if c.app.Debug {
+				panic(err)
+			}
+			return err
+		}
+		c.called[i] = true
+	}
+
+	if i+1 >= len(c.handlers) {
+		return nil
+	}
+
+	c.index = i + 1
+	err := c.handlers[c.index](c)
+	c.index = i
+	return err
+}
+
+// run runs handlers as a chain nested in the current one, restoring it
+// once done.
+func (c *Context) run(handlers []HandlerFunc) error {
+	// the nested chain tracks its calls past the outer ones, growing the
+	// outer slice so the pooled context keeps the capacity
+	outer, called := c.handlers, slices.Grow(c.called, len(handlers))
+	c.handlers, c.index = handlers, -1
+	c.called = called[len(called) : len(called)+len(handlers)]
+	clear(c.called)
+
+	err := c.Next()
+	c.handlers, c.called = outer, called
+	return err
 }
 
 func (c *Context) writeHeader(code []int) {
@@ -56,6 +152,98 @@ func (c *Context) Param(name string) string {
 	return c.Req.PathValue(name)
 }
 
+// Accepts returns the offered media type the client prefers according to
+// the Accept header, or "" when it accepts none of them. Parameters of the
+// offers, like the charset, are ignored. Without an Accept header the first
+// offer is returned.
+func (c *Context) Accepts(offers ...string) string {
+	accept := strings.Join(c.Req.Header.Values(HeaderAccept), ",")
+	if accept == "" && len(offers) > 0 {
+		return offers[0]
+	}
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := acceptQuality(accept, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// acceptQuality returns the quality the most specific media range of the
+// Accept header matching the media type gives it.
+func acceptQuality(accept, mediaType string) float64 {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	typ, _, _ := strings.Cut(mediaType, "/")
+
+	q, specificity := 0.0, -1
+	for _, r := range strings.Split(accept, ",") {
+		mediaRange, params, _ := strings.Cut(r, ";")
+		var s int
+		switch strings.ToLower(strings.TrimSpace(mediaRange)) {
+		case mediaType:
+			s = 2
+		case typ + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			specificity, q = s, quality(params)
+		}
+	}
+	return q
+}
+
+// quality returns the q parameter of a media range, 1 by default.
+func quality(params string) float64 {
+	for _, p := range strings.Split(params, ";") {
+		k, v, _ := strings.Cut(p, "=")
+		if strings.TrimSpace(k) != "q" {
+			continue
+		}
+		if q, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return q
+		}
+	}
+	return 1
+}
+
+// Logger returns the App.Logger with the request ID, method, path and, once
+// routed, route pattern of the request. The ID is the X-Request-Id header of
+// the request, see middleware.RequestID to make sure every request has one.
+func (c *Context) Logger() *slog.Logger {
+	if c.logger == nil {
+		attrs := make([]any, 0, 4)
+		if id := c.Req.Header.Get(HeaderXRequestID); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+		attrs = append(attrs, slog.String("method", c.Req.Method), slog.String("path", c.Path()))
+		if c.route != nil && c.route.Path != "" {
+			attrs = append(attrs, slog.String("route", c.route.Path))
+		}
+		c.logger = c.app.logger().With(attrs...)
+	}
+	return c.logger
+}
+
+// Error handles err right away, like errors returned by the chain: it runs
+// the OnError hooks and the ErrorHandler, or logs err once the response is
+// committed. Middleware uses it to observe the response written for an
+// error, and should then return nil so the error is not handled twice.
+func (c *Context) Error(err error) {
+	c.app.catch(err, c)
+}
+
 func (c *Context) NotFound() error {
 	return c.app.NotFoundHandler(c)
 }
+
+// URLFor builds the URL of the named route. See Route.URL.
+func (c *Context) URLFor(name string, params ...any) (string, error) {
+	return c.app.URL(name, params...)
+}
@@ -0,0 +1,184 @@
+package lit
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Cookie returns the named request cookie or ErrCookieNotFound.
+func (c *Context) Cookie(name string) (*http.Cookie, error) {
+	cookie, err := c.Req.Cookie(name)
+	if errors.Is(err, http.ErrNoCookie) {
+		return nil, ErrCookieNotFound
+	}
+	return cookie, err
+}
+
+// SetCookie adds the cookie to the response.
+func (c *Context) SetCookie(cookie *http.Cookie) {
+	http.SetCookie(c.Res, cookie)
+}
+
+// ClearCookie tells the client to delete the named cookie.
+func (c *Context) ClearCookie(name string) {
+	c.SetCookie(&http.Cookie{
+		Name:    name,
+		Path:    "/",
+		MaxAge:  -1,
+		Expires: time.Unix(0, 0),
+	})
+}
+
+// SetSignedCookie adds the cookie to the response with its value signed
+// with HMAC-SHA256 using the first of App.CookieKeys. The value remains
+// readable by the client, see SetEncryptedCookie to hide it.
+func (c *Context) SetSignedCookie(cookie *http.Cookie) error {
+	keys, err := c.app.cookieKeys("sign")
+	if err != nil {
+		return err
+	}
+
+	signed := *cookie
+	signed.Value = encode([]byte(cookie.Value)) + "." + encode(sign(keys[0], cookie.Name, cookie.Value))
+	c.SetCookie(&signed)
+	return nil
+}
+
+// SignedCookie returns the value of a cookie set with SetSignedCookie,
+// checking its signature against every key of App.CookieKeys. A missing
+// cookie results in ErrCookieNotFound and an invalid one in
+// ErrCookieTampered.
+func (c *Context) SignedCookie(name string) (string, error) {
+	keys, err := c.app.cookieKeys("sign")
+	if err != nil {
+		return "", err
+	}
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+
+	v, mac, ok := strings.Cut(cookie.Value, ".")
+	if !ok {
+		return "", ErrCookieTampered
+	}
+	value, err := decode(v)
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+	sum, err := decode(mac)
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+
+	for _, key := range keys {
+		if hmac.Equal(sum, sign(key, name, string(value))) {
+			return string(value), nil
+		}
+	}
+	return "", ErrCookieTampered
+}
+
+// SetEncryptedCookie adds the cookie to the response with its value
+// encrypted with AES-GCM using the first of App.CookieKeys.
+func (c *Context) SetEncryptedCookie(cookie *http.Cookie) error {
+	keys, err := c.app.cookieKeys("encrypt")
+	if err != nil {
+		return err
+	}
+
+	aead, err := newGCM(keys[0])
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	encrypted := *cookie
+	encrypted.Value = encode(aead.Seal(nonce, nonce, []byte(cookie.Value), []byte(cookie.Name)))
+	c.SetCookie(&encrypted)
+	return nil
+}
+
+// EncryptedCookie returns the value of a cookie set with
+// SetEncryptedCookie, trying every key of App.CookieKeys. A missing cookie
+// results in ErrCookieNotFound and one that does not decrypt in
+// ErrCookieTampered.
+func (c *Context) EncryptedCookie(name string) (string, error) {
+	keys, err := c.app.cookieKeys("encrypt")
+	if err != nil {
+		return "", err
+	}
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := decode(cookie.Value)
+	if err != nil {
+		return "", ErrCookieTampered
+	}
+
+	for _, key := range keys {
+		aead, err := newGCM(key)
+		if err != nil {
+			return "", err
+		}
+		if len(data) < aead.NonceSize() {
+			return "", ErrCookieTampered
+		}
+		nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+		if value, err := aead.Open(nil, nonce, ciphertext, []byte(name)); err == nil {
+			return string(value), nil
+		}
+	}
+	return "", ErrCookieTampered
+}
+
+// cookieKeys derives a key for the purpose from each of App.CookieKeys, so
+// the same secret is not used both to sign and to encrypt.
+func (a *App) cookieKeys(purpose string) ([][]byte, error) {
+	if len(a.CookieKeys) == 0 {
+		return nil, ErrCookieKeysNotSet
+	}
+
+	keys := make([][]byte, len(a.CookieKeys))
+	for i, secret := range a.CookieKeys {
+		h := hmac.New(sha256.New, secret)
+		h.Write([]byte("lit-cookie-" + purpose))
+		keys[i] = h.Sum(nil)
+	}
+	return keys, nil
+}
+
+func sign(key []byte, name, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(name + "=" + value))
+	return h.Sum(nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,111 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCookies(t *testing.T) {
+	app := lit.New()
+	app.CookieKeys = [][]byte{[]byte("old secret")}
+
+	app.GET("/set", func(c *lit.Context) error {
+		c.SetCookie(&http.Cookie{Name: "plain", Value: "a"})
+		if err := c.SetSignedCookie(&http.Cookie{Name: "signed", Value: "user 42"}); err != nil {
+			return err
+		}
+		return c.SetEncryptedCookie(&http.Cookie{Name: "secret", Value: "token"})
+	})
+
+	var got map[string]string
+	var errs map[string]error
+	app.GET("/get", func(c *lit.Context) error {
+		got, errs = map[string]string{}, map[string]error{}
+		if cookie, err := c.Cookie("plain"); err == nil {
+			got["plain"] = cookie.Value
+		} else {
+			errs["plain"] = err
+		}
+		got["signed"], errs["signed"] = c.SignedCookie("signed")
+		got["secret"], errs["secret"] = c.EncryptedCookie("secret")
+		return nil
+	})
+
+	app.GET("/clear", func(c *lit.Context) error {
+		c.ClearCookie("plain")
+		return nil
+	})
+
+	set := func() []*http.Cookie {
+		return makeRequest(app, "GET", "/set", nil, false).Result().Cookies()
+	}
+
+	get := func(cookies []*http.Cookie) {
+		req := httptest.NewRequest("GET", "/get", nil)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	cookies := set()
+
+	t.Run("round trip", func(t *testing.T) {
+		get(cookies)
+
+		assert.Equal(t, map[string]string{"plain": "a", "signed": "user 42", "secret": "token"}, got)
+		assert.NotContains(t, cookies[2].Value, "token")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		get(nil)
+
+		assert.ErrorIs(t, errs["plain"], lit.ErrCookieNotFound)
+		assert.ErrorIs(t, errs["signed"], lit.ErrCookieNotFound)
+		assert.ErrorIs(t, errs["secret"], lit.ErrCookieNotFound)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		signed, secret := *cookies[1], *cookies[2]
+		signed.Value = "dXNlciAx" + signed.Value[len("dXNlciA0Mg"):]
+		secret.Value = "x" + secret.Value[1:]
+		get([]*http.Cookie{&signed, &secret})
+
+		assert.ErrorIs(t, errs["signed"], lit.ErrCookieTampered)
+		assert.ErrorIs(t, errs["secret"], lit.ErrCookieTampered)
+	})
+
+	t.Run("key rotation", func(t *testing.T) {
+		app.CookieKeys = [][]byte{[]byte("new secret"), []byte("old secret")}
+		get(cookies)
+		assert.Equal(t, "user 42", got["signed"])
+		assert.Equal(t, "token", got["secret"])
+
+		app.CookieKeys = [][]byte{[]byte("new secret")}
+		get(cookies)
+		assert.ErrorIs(t, errs["signed"], lit.ErrCookieTampered)
+		assert.ErrorIs(t, errs["secret"], lit.ErrCookieTampered)
+
+		get(set())
+		assert.Equal(t, "user 42", got["signed"])
+		assert.Equal(t, "token", got["secret"])
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/clear", nil, false)
+		cookie := w.Result().Cookies()[0]
+
+		assert.Equal(t, "plain", cookie.Name)
+		assert.Equal(t, -1, cookie.MaxAge)
+	})
+
+	t.Run("keys not set", func(t *testing.T) {
+		app.CookieKeys = nil
+		get(cookies)
+		assert.ErrorIs(t, errs["signed"], lit.ErrCookieKeysNotSet)
+	})
+}
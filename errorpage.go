@@ -0,0 +1,104 @@
+package lit
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// ErrorPage is the data the App.ErrorTemplate is executed with.
+type ErrorPage struct {
+	Code     int
+	Title    string // status text of the code
+	Message  string
+	Internal string // internal error, only in debug mode
+	Stack    string // stack of a recovered panic, only in debug mode
+}
+
+var defaultErrorTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Code}} {{.Title}}</title>
+</head>
+<body>
+<h1>{{.Code}} {{.Title}}</h1>
+{{if ne .Message .Title}}<p>{{.Message}}</p>
+{{end}}{{with .Internal}}<pre>{{.}}</pre>
+{{end}}{{with .Stack}}<pre>{{.}}</pre>
+{{end}}</body>
+</html>
+`))
+
+// errorDebug holds what debug mode adds to error responses.
+type errorDebug struct {
+	Internal string `json:"internal,omitempty"`
+	Stack    string `json:"stack,omitempty"`
+}
+
+// newErrorDebug describes the internal error of httpErr, or err itself when
+// it is not an HTTPError.
+func newErrorDebug(err error, httpErr *HTTPError) *errorDebug {
+	internal := httpErr.Internal
+	if !errors.As(err, new(*HTTPError)) {
+		internal = err
+	}
+
+	d := &errorDebug{}
+	if internal != nil {
+		d.Internal = internal.Error()
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		d.Stack = string(panicErr.Stack)
+	}
+	return d
+}
+
+func renderJSON(c *Context, httpErr *HTTPError, debug *errorDebug) error {
+	if debug == nil {
+		return c.JSON(httpErr, httpErr.Code)
+	}
+	return c.JSON(struct {
+		*HTTPError
+		*errorDebug
+	}{httpErr, debug}, httpErr.Code)
+}
+
+func renderHTML(c *Context, httpErr *HTTPError, debug *errorDebug) error {
+	page := ErrorPage{
+		Code:    httpErr.Code,
+		Title:   http.StatusText(httpErr.Code),
+		Message: fmt.Sprint(httpErr.Message),
+	}
+	if debug != nil {
+		page.Internal, page.Stack = debug.Internal, debug.Stack
+	}
+
+	tmpl := c.app.ErrorTemplate
+	if tmpl == nil {
+		tmpl = defaultErrorTemplate
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, page); err != nil {
+		return err
+	}
+	return c.HTML(b.String(), httpErr.Code)
+}
+
+func renderText(c *Context, httpErr *HTTPError, debug *errorDebug) error {
+	var b strings.Builder
+	fmt.Fprintln(&b, httpErr.Message)
+	if debug != nil {
+		if debug.Internal != "" {
+			fmt.Fprintf(&b, "\ninternal: %s\n", debug.Internal)
+		}
+		if debug.Stack != "" {
+			fmt.Fprintf(&b, "\n%s", debug.Stack)
+		}
+	}
+	return c.Text(b.String(), httpErr.Code)
+}
@@ -0,0 +1,126 @@
+package lit_test
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccepts(t *testing.T) {
+	offers := []string{lit.MIMEApplicationJSON, lit.MIMETextHTML, lit.MIMETextPlain}
+
+	cases := []struct {
+		accept string
+		want   string
+	}{
+		{"", lit.MIMEApplicationJSON},
+		{"*/*", lit.MIMEApplicationJSON},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", lit.MIMETextHTML},
+		{"text/*", lit.MIMETextHTML},
+		{"text/*;q=0.5, text/plain", lit.MIMETextPlain},
+		{"application/json;q=0.2, text/plain;q=0.4", lit.MIMETextPlain},
+		{"TEXT/PLAIN", lit.MIMETextPlain},
+		{"*/*;q=0.1, text/html;q=0", lit.MIMEApplicationJSON},
+		{"image/png", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.accept, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.accept != "" {
+				req.Header.Set(lit.HeaderAccept, tc.accept)
+			}
+
+			var got string
+			lit.New().NewHandler(func(c *lit.Context) error {
+				got = c.Accepts(offers...)
+				return nil
+			}).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestErrorRendering(t *testing.T) {
+	app := lit.New(lit.WithRecover())
+	app.GET("/db", func(c *lit.Context) error {
+		return lit.ErrServiceUnavailable.WithInternal(errors.New("dial tcp: connection refused"))
+	})
+	app.GET("/plain", func(c *lit.Context) error {
+		return errors.New("secret token expired")
+	})
+	app.GET("/panic", func(c *lit.Context) error {
+		panic("nil map")
+	})
+
+	request := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set(lit.HeaderAccept, accept)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("production", func(t *testing.T) {
+		w := request("/db", "application/json")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, lit.MIMEApplicationJSON, w.Header().Get(lit.HeaderContentType))
+		assert.JSONEq(t, `{"message":"Service Unavailable"}`, w.Body.String())
+
+		w = request("/db", "text/html")
+		assert.Equal(t, lit.MIMETextHTML, w.Header().Get(lit.HeaderContentType))
+		assert.Contains(t, w.Body.String(), "<h1>503 Service Unavailable</h1>")
+		assert.NotContains(t, w.Body.String(), "refused")
+
+		w = request("/plain", "text/plain")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, lit.MIMETextPlain, w.Header().Get(lit.HeaderContentType))
+		assert.Equal(t, "Internal Server Error\n", w.Body.String())
+
+		for _, accept := range []string{"application/json", "text/html", "text/plain"} {
+			w = request("/panic", accept)
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.NotContains(t, w.Body.String(), "nil map")
+			assert.NotContains(t, w.Body.String(), "goroutine")
+		}
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		app.Debug = true
+		defer func() { app.Debug = false }()
+
+		w := request("/db", "application/json")
+		assert.JSONEq(t, `{"message":"Service Unavailable","internal":"dial tcp: connection refused"}`, w.Body.String())
+
+		w = request("/plain", "text/html")
+		assert.Contains(t, w.Body.String(), "<pre>secret token expired</pre>")
+
+		w = request("/plain", "text/plain")
+		assert.Equal(t, "Internal Server Error\n\ninternal: secret token expired\n", w.Body.String())
+
+		w = request("/panic", "application/json")
+		body, err := decode[lit.Map](w.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "panic: nil map", body["internal"])
+		assert.Contains(t, body["stack"], "errorpage_test.go")
+
+		w = request("/panic", "text/html")
+		assert.Contains(t, w.Body.String(), "<pre>panic: nil map</pre>")
+		assert.Contains(t, w.Body.String(), "goroutine")
+	})
+
+	t.Run("template", func(t *testing.T) {
+		app.ErrorTemplate = template.Must(template.New("").Parse(`<p>{{.Code}}: {{.Message}}</p>`))
+		defer func() { app.ErrorTemplate = nil }()
+
+		w := request("/missing", "text/html")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "<p>404: Not Found</p>", w.Body.String())
+	})
+}
@@ -3,6 +3,7 @@ package lit
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 )
 
@@ -48,18 +49,34 @@ var (
 	ErrNotExtended                   = NewError(http.StatusNotExtended)                   // HTTP 510 Not Extended
 	ErrNetworkAuthenticationRequired = NewError(http.StatusNetworkAuthenticationRequired) // HTTP 511 Network Authentication Required
 
+	// Deprecated: Context.Next returns nil at the end of the chain.
 	ErrNoNextHandler          = errors.New("no next handler")
+	ErrNextCalledTwice        = errors.New("next called more than once")
 	ErrValidatorNotRegistered = errors.New("validator not registered")
+	ErrInvalidValidationRule  = errors.New("invalid validation rule")
 	ErrInvalidRedirectCode    = errors.New("invalid redirect status code")
+	ErrUnsafeRedirect         = errors.New("redirect to host not allowed")
 	ErrCookieNotFound         = errors.New("cookie not found")
+	ErrCookieTampered         = errors.New("cookie tampered")
+	ErrCookieKeysNotSet       = errors.New("cookie keys not set")
 	ErrInvalidCertOrKeyType   = errors.New("invalid cert or key type, must be string or []byte")
 	ErrInvalidListenerNetwork = errors.New("invalid listener network")
+	ErrServerStarted          = errors.New("server already started")
+	ErrRouteNotFound          = errors.New("route not found")
 )
 
+// HTTPError is an error answered with its status code. The package level
+// errors, like ErrNotFound, are shared by every request and nothing stops
+// writes to their fields: derive new errors from them with the With
+// methods, which return copies, instead. The copies still match the
+// original with errors.Is, see Is.
 type HTTPError struct {
-	Code     int   `json:"-"`
-	Message  any   `json:"message"`
-	Internal error `json:"-"` // Stores the error from an external dependency.
+	Code      int    `json:"-"`
+	Message   any    `json:"message"`
+	ErrorCode string `json:"code,omitempty"`    // application error code, like "USER_NOT_FOUND"
+	Details   Map    `json:"details,omitempty"` // additional data, extension members with ProblemDetails
+	Internal  error  `json:"-"`                 // Stores the error from an external dependency.
+	Type      string `json:"-"`                 // URI identifying the kind of problem, see ProblemDetails
 }
 
 func (e *HTTPError) Error() string {
@@ -69,6 +86,55 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("code: %d, message: %s", e.Code, e.Message)
 }
 
+// Unwrap returns the internal error, so errors.Is and errors.As reach it.
+func (e *HTTPError) Unwrap() error {
+	return e.Internal
+}
+
+// Is reports whether target is an HTTPError with the same status code, so
+// errors derived from ErrNotFound still match it.
+func (e *HTTPError) Is(target error) bool {
+	t, ok := target.(*HTTPError)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage returns a copy of e with the message.
+func (e *HTTPError) WithMessage(msg any) *HTTPError {
+	c := *e
+	c.Message = msg
+	return &c
+}
+
+// WithInternal returns a copy of e wrapping err.
+func (e *HTTPError) WithInternal(err error) *HTTPError {
+	c := *e
+	c.Internal = err
+	return &c
+}
+
+// WithCode returns a copy of e with the application error code.
+func (e *HTTPError) WithCode(code string) *HTTPError {
+	c := *e
+	c.ErrorCode = code
+	return &c
+}
+
+// WithDetails returns a copy of e with details added to its own.
+func (e *HTTPError) WithDetails(details Map) *HTTPError {
+	c := *e
+	c.Details = make(Map, len(e.Details)+len(details))
+	maps.Copy(c.Details, e.Details)
+	maps.Copy(c.Details, details)
+	return &c
+}
+
+// WithType returns a copy of e with the problem type URI.
+func (e *HTTPError) WithType(uri string) *HTTPError {
+	c := *e
+	c.Type = uri
+	return &c
+}
+
 func NewError(code int, msg ...string) *HTTPError {
 	message := http.StatusText(code)
 	if len(msg) > 0 {
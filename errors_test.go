@@ -0,0 +1,128 @@
+package lit_test
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPError(t *testing.T) {
+	t.Run("builders copy", func(t *testing.T) {
+		e := lit.ErrNotFound.
+			WithMessage("user not found").
+			WithCode("USER_NOT_FOUND").
+			WithDetails(lit.Map{"id": 7}).
+			WithInternal(sql.ErrNoRows)
+
+		assert.Equal(t, http.StatusNotFound, e.Code)
+		assert.Equal(t, "user not found", e.Message)
+		assert.Equal(t, "USER_NOT_FOUND", e.ErrorCode)
+		assert.Equal(t, lit.Map{"id": 7}, e.Details)
+
+		assert.Equal(t, "Not Found", lit.ErrNotFound.Message)
+		assert.Empty(t, lit.ErrNotFound.ErrorCode)
+		assert.Nil(t, lit.ErrNotFound.Details)
+		assert.Nil(t, lit.ErrNotFound.Internal)
+	})
+
+	t.Run("is", func(t *testing.T) {
+		err := fmt.Errorf("get user: %w", lit.ErrNotFound.WithMessage("x").WithCode("USER_NOT_FOUND"))
+
+		assert.ErrorIs(t, err, lit.ErrNotFound)
+		assert.ErrorIs(t, lit.ErrNotFound.WithInternal(sql.ErrNoRows), lit.ErrNotFound)
+		assert.NotErrorIs(t, err, lit.ErrBadRequest)
+		assert.NotErrorIs(t, lit.ErrNotFound, lit.ErrNoNextHandler)
+	})
+
+	t.Run("details merge", func(t *testing.T) {
+		base := lit.ErrBadRequest.WithDetails(lit.Map{"a": 1})
+		e := base.WithDetails(lit.Map{"b": 2})
+
+		assert.Equal(t, lit.Map{"a": 1}, base.Details)
+		assert.Equal(t, lit.Map{"a": 1, "b": 2}, e.Details)
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		err := fmt.Errorf("get user: %w", lit.ErrNotFound.WithInternal(sql.ErrNoRows))
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		var httpErr *lit.HTTPError
+		assert.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	})
+
+	t.Run("response", func(t *testing.T) {
+		app := lit.New()
+		app.GET("/user", func(c *lit.Context) error {
+			return lit.ErrNotFound.WithMessage("user not found").WithCode("USER_NOT_FOUND").WithDetails(lit.Map{"id": 7})
+		})
+		app.GET("/wrapped", func(c *lit.Context) error {
+			return lit.ErrBadGateway.WithInternal(lit.ErrNotFound)
+		})
+		app.GET("/plain", func(c *lit.Context) error {
+			return lit.ErrConflict.WithInternal(errors.New("duplicate key"))
+		})
+
+		w := makeRequest(app, "GET", "/user", nil, false)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"message":"user not found","code":"USER_NOT_FOUND","details":{"id":7}}`, w.Body.String())
+
+		w = makeRequest(app, "GET", "/wrapped", nil, false)
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+
+		w = makeRequest(app, "GET", "/plain", nil, false)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"message":"Conflict"}`, w.Body.String())
+	})
+}
+
+func TestCommittedError(t *testing.T) {
+	var logs bytes.Buffer
+	app := lit.New(lit.WithRecover())
+	app.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	var hooked []error
+	app.OnError(func(err error, c *lit.Context) {
+		hooked = append(hooked, err)
+	})
+
+	called := false
+	handler := app.ErrorHandler
+	app.ErrorHandler = func(err error, c *lit.Context) {
+		called = true
+		handler(err, c)
+	}
+
+	app.GET("/late", func(c *lit.Context) error {
+		c.Text("partial")
+		return errors.New("flush failed")
+	})
+	app.GET("/panic", func(c *lit.Context) error {
+		c.Text("partial")
+		panic("late")
+	})
+
+	w := makeRequest(app, "GET", "/late", nil, false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+	assert.False(t, called)
+	assert.EqualError(t, hooked[0], "flush failed")
+	assert.Contains(t, logs.String(), `level=ERROR msg="error after response committed"`)
+	assert.Contains(t, logs.String(), `method=GET path=/late route=/late error="flush failed" status=200`)
+
+	logs.Reset()
+	w = makeRequest(app, "GET", "/panic", nil, false)
+	assert.Equal(t, "partial", w.Body.String())
+	assert.False(t, called)
+	assert.Len(t, hooked, 2)
+	assert.Contains(t, logs.String(), "path=/panic")
+	assert.Contains(t, logs.String(), "stack=")
+}
@@ -0,0 +1,81 @@
+// Package fmt renders lit values for the terminal.
+package fmt
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/jocades/lit"
+	"github.com/jocades/lit/color"
+)
+
+// PrintRoutes prints the routes of the app to stdout.
+func PrintRoutes(app *lit.App) {
+	Routes(os.Stdout, app.Routes())
+}
+
+// Routes writes the routes to w as a colored table.
+func Routes(w io.Writer, routes []lit.RouteInfo) {
+	header := []string{"METHOD", "PATTERN", "NAME", "HANDLER", "MIDDLEWARE"}
+	rows := make([][]string, len(routes))
+	for i, r := range routes {
+		method := r.Method
+		if method == "" {
+			method = "*"
+		}
+		rows[i] = []string{method, r.Pattern, r.Name, r.Handler, strings.Join(r.Middleware, ", ")}
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	line := func(row []string, paint func(i int, s string) string) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if i < len(row)-1 {
+				cell += strings.Repeat(" ", widths[i]-len(cell))
+			}
+			cells[i] = paint(i, cell)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "  "))
+	}
+
+	line(header, func(_ int, s string) string { return color.Gray(s) })
+	for _, row := range rows {
+		line(row, func(i int, s string) string {
+			switch i {
+			case 0:
+				return method(row[0], s)
+			case 1:
+				return color.Cyan(s)
+			case 4:
+				return color.Gray(s)
+			default:
+				return s
+			}
+		})
+	}
+}
+
+// method colors s according to the HTTP method m.
+func method(m, s string) string {
+	switch m {
+	case http.MethodGet:
+		return color.Green(s)
+	case http.MethodPost:
+		return color.Yellow(s)
+	case http.MethodPut, http.MethodPatch:
+		return color.Blue(s)
+	case http.MethodDelete:
+		return color.Red(s)
+	default:
+		return color.Magenta(s)
+	}
+}
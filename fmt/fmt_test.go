@@ -0,0 +1,31 @@
+package fmt_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jocades/lit"
+	litfmt "github.com/jocades/lit/fmt"
+	"github.com/stretchr/testify/assert"
+)
+
+var ansi = regexp.MustCompile("\033\\[[0-9;]*m")
+
+func TestRoutes(t *testing.T) {
+	var b bytes.Buffer
+	litfmt.Routes(&b, []lit.RouteInfo{
+		{Method: "GET", Pattern: "/users/{id}", Name: "user.show", Handler: "main.show", Middleware: []string{"main.auth", "main.log"}},
+		{Method: "", Pattern: "/admin/", Handler: "*http.ServeMux"},
+	})
+
+	assert.Contains(t, b.String(), "\033[32mGET", "methods are colored")
+
+	lines := strings.Split(strings.TrimSuffix(ansi.ReplaceAllString(b.String(), ""), "\n"), "\n")
+	assert.Equal(t, []string{
+		"METHOD  PATTERN      NAME       HANDLER         MIDDLEWARE",
+		"GET     /users/{id}  user.show  main.show       main.auth, main.log",
+		"*       /admin/                 *http.ServeMux  ",
+	}, lines)
+}
@@ -0,0 +1,96 @@
+package lit
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Group is a set of routes sharing a path prefix and middleware.
+//
+// Middleware runs from the outermost scope inwards: app, parent groups, the
+// group itself and finally the route handlers.
+type Group struct {
+	app        *App
+	parent     *Group
+	prefix     string
+	middleware []HandlerFunc
+}
+
+// Group creates a route group under the prefix.
+func (a *App) Group(prefix string, mw ...HandlerFunc) *Group {
+	return &Group{app: a, prefix: prefix, middleware: mw}
+}
+
+// Group creates a nested group whose prefix is appended to the parent's.
+func (g *Group) Group(prefix string, mw ...HandlerFunc) *Group {
+	return &Group{app: g.app, parent: g, prefix: prefix, middleware: mw}
+}
+
+// Use registers middleware for the routes of the group and its subgroups,
+// including the routes registered before it.
+func (g *Group) Use(h HandlerFunc) {
+	g.middleware = append(g.middleware, h)
+	g.app.rebuild()
+}
+
+// Prefix returns the full path prefix of the group.
+func (g *Group) Prefix() string {
+	if g.parent == nil {
+		return g.prefix
+	}
+	return joinPath(g.parent.Prefix(), g.prefix)
+}
+
+// chain returns the middleware of the group and its parents, outermost first.
+func (g *Group) chain() []HandlerFunc {
+	if g == nil {
+		return nil
+	}
+	return slices.Concat(g.parent.chain(), g.middleware)
+}
+
+// Add registers a route relative to the group prefix. The paths "" and "/"
+// both refer to the prefix itself.
+func (g *Group) Add(method, path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return g.app.add(method, joinPath(g.Prefix(), path), g, h, hs)
+}
+
+// Mount serves h under the group prefix joined with prefix.
+func (g *Group) Mount(prefix string, h http.Handler) {
+	g.app.mount(joinPath(g.Prefix(), prefix), g, h)
+}
+
+func (g *Group) GET(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return g.Add(http.MethodGet, path, h, hs...)
+}
+
+func (g *Group) POST(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return g.Add(http.MethodPost, path, h, hs...)
+}
+
+func (g *Group) PUT(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return g.Add(http.MethodPut, path, h, hs...)
+}
+
+func (g *Group) PATCH(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return g.Add(http.MethodPatch, path, h, hs...)
+}
+
+func (g *Group) DELETE(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return g.Add(http.MethodDelete, path, h, hs...)
+}
+
+func joinPath(prefix, path string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if path == "" || IsRoot(path) {
+		if prefix == "" {
+			return "/"
+		}
+		return prefix
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return prefix + path
+}
@@ -0,0 +1,68 @@
+package lit_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func trace(name string) lit.HandlerFunc {
+	return func(c *lit.Context) error {
+		c.Header.Add("X-Trace", name)
+		return c.Next()
+	}
+}
+
+func TestGroup(t *testing.T) {
+	app := lit.New()
+	app.Use(trace("app"))
+
+	api := app.Group("/api", trace("api"))
+	v1 := api.Group("/v1", trace("v1"))
+	users := v1.Group("/users")
+	users.Use(trace("users"))
+
+	users.GET("/", func(c *lit.Context) error {
+		return c.Text("list")
+	})
+
+	users.GET("/{id}", trace("route"), func(c *lit.Context) error {
+		return c.Text("user " + c.Param("id"))
+	})
+
+	api.POST("/ping", func(c *lit.Context) error {
+		return c.Text("pong")
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		assert.Equal(t, "/api/v1/users", users.Prefix())
+	})
+
+	t.Run("group root", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/api/v1/users", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "list", w.Body.String())
+	})
+
+	t.Run("middleware order", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/api/v1/users/1", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "user 1", w.Body.String())
+		assert.Equal(t,
+			"app,api,v1,users,route",
+			strings.Join(w.Header().Values("X-Trace"), ","),
+		)
+	})
+
+	t.Run("sibling scope", func(t *testing.T) {
+		w := makeRequest(app, "POST", "/api/ping", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "app,api", strings.Join(w.Header().Values("X-Trace"), ","))
+	})
+}
@@ -0,0 +1,115 @@
+package lit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"time"
+)
+
+// hooks are the functions registered to run around the app lifecycle.
+type hooks struct {
+	start    []func(addr net.Addr)
+	shutdown []shutdownHook
+	route    []func(r *Route)
+	error    []ErrHandlerFunc
+}
+
+type shutdownHook struct {
+	h       func(ctx context.Context) error
+	timeout time.Duration
+}
+
+// OnStart registers h to run once the app listens, before it serves the
+// first request, with the bound address.
+func (a *App) OnStart(h func(addr net.Addr)) {
+	a.hooks.start = append(a.hooks.start, h)
+}
+
+// OnShutdown registers h to run when the app shuts down, once the in-flight
+// requests are drained. Hooks run one after the other in the order they are
+// registered, each with a context done after its own timeout, or never for
+// a zero timeout. Their errors are returned by Shutdown.
+func (a *App) OnShutdown(h func(ctx context.Context) error, timeout time.Duration) {
+	a.hooks.shutdown = append(a.hooks.shutdown, shutdownHook{h, timeout})
+}
+
+// OnRoute registers h to run for every route as it is registered, starting
+// with the routes registered so far. Properties set on the route afterwards,
+// like its name, are only visible to h through the pointer it receives.
+func (a *App) OnRoute(h func(r *Route)) {
+	for _, r := range a.routes {
+		h(r)
+	}
+	a.hooks.route = append(a.hooks.route, h)
+}
+
+// OnError registers h to run for every error passed to the ErrorHandler,
+// before it handles it.
+func (a *App) OnError(h ErrHandlerFunc) {
+	a.hooks.error = append(a.hooks.error, h)
+}
+
+// addRoute records the route and runs the OnRoute hooks.
+func (a *App) addRoute(r *Route) {
+	a.routes = append(a.routes, r)
+	for _, h := range a.hooks.route {
+		h(r)
+	}
+}
+
+// catch runs the OnError hooks and the ErrorHandler. Once the response is
+// committed the error can no longer be answered, so it is logged instead of
+// passed to the ErrorHandler.
+func (a *App) catch(err error, c *Context) {
+	for _, h := range a.hooks.error {
+		h(err, c)
+	}
+
+	if !c.Res.Commited {
+		a.ErrorHandler(err, c)
+		return
+	}
+
+	attrs := []any{slog.Any("error", err), slog.Int("status", c.Res.Status)}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		attrs = append(attrs, slog.String("stack", string(panicErr.Stack)))
+	}
+	c.Logger().Error("error after response committed", attrs...)
+}
+
+// logger returns the App.Logger, or the default one.
+func (a *App) logger() *slog.Logger {
+	if a.Logger != nil {
+		return a.Logger
+	}
+	return slog.Default()
+}
+
+func (a *App) started(addr net.Addr) {
+	for _, h := range a.hooks.start {
+		h(addr)
+	}
+}
+
+// stopped runs the OnShutdown hooks. Their contexts keep the values of ctx
+// but not its deadline, which the server may have used up draining requests.
+func (a *App) stopped(ctx context.Context) error {
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for _, hook := range a.hooks.shutdown {
+		errs = append(errs, runShutdownHook(ctx, hook))
+	}
+	return errors.Join(errs...)
+}
+
+func runShutdownHook(ctx context.Context, hook shutdownHook) error {
+	if hook.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hook.timeout)
+		defer cancel()
+	}
+	return hook.h(ctx)
+}
@@ -0,0 +1,91 @@
+package lit_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnRoute(t *testing.T) {
+	app := lit.New()
+	app.GET("/before", func(c *lit.Context) error { return nil })
+
+	var patterns []string
+	app.OnRoute(func(r *lit.Route) {
+		patterns = append(patterns, r.Method+" "+r.Path)
+	})
+
+	app.POST("/after", func(c *lit.Context) error { return nil })
+	app.Group("/api").GET("/users", func(c *lit.Context) error { return nil })
+	app.Mount("/mux", http.NewServeMux())
+
+	assert.Equal(t, []string{"GET /before", "POST /after", "GET /api/users", " /mux/"}, patterns)
+}
+
+func TestOnError(t *testing.T) {
+	app := lit.New()
+
+	var errs []error
+	app.OnError(func(err error, c *lit.Context) {
+		assert.Equal(t, "/fail", c.Path())
+		errs = append(errs, err)
+	})
+
+	boom := errors.New("boom")
+	app.GET("/fail", func(c *lit.Context) error { return boom })
+	app.GET("/ok", func(c *lit.Context) error { return nil })
+
+	w := makeRequest(app, "GET", "/fail", nil, false)
+	makeRequest(app, "GET", "/ok", nil, false)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, []error{boom}, errs)
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	app := lit.New()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	var addr net.Addr
+	app.OnStart(func(a net.Addr) { addr = a })
+
+	var order []string
+	app.OnShutdown(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		order = append(order, "db")
+		return nil
+	}, time.Second)
+	app.OnShutdown(func(ctx context.Context) error {
+		<-ctx.Done()
+		order = append(order, "telemetry")
+		return ctx.Err()
+	}, time.Millisecond)
+	app.OnShutdown(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		order = append(order, "last")
+		return nil
+	}, 0)
+
+	served := make(chan error, 1)
+	go func() { served <- app.Listener(l) }()
+	waitStarted(t, app)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = app.Shutdown(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NoError(t, <-served)
+	assert.Equal(t, l.Addr(), addr)
+	assert.Equal(t, []string{"db", "telemetry", "last"}, order)
+}
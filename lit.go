@@ -4,8 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"html/template"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
 type Map map[string]any
@@ -14,32 +20,41 @@ type HandlerFunc func(c *Context) error
 
 type ErrHandlerFunc func(err error, c *Context)
 
+// handleError is the default ErrorHandler. It answers with JSON, the
+// ErrorTemplate or plain text, whichever the client accepts best. In debug
+// mode the response also shows the internal error and the stack of a
+// recovered panic, which are never sent otherwise.
 func handleError(err error, c *Context) {
-	// if c.Res.Commited {
-	// if the response has already been committed, log the error and return
-	// this is a safety measure to prevent writing to a closed connection
-	// also prevents the error from being written to the response but it is
-	// not handled at the app level it will be lost unless we handle it here.
-	//
-	// just log it here for now
-	// fmt.Printf("error in committed response: %v\n", err)
-	// return
-	// }
+	httpErr := toHTTPError(err, c)
 
-	var httpErr *HTTPError
-
-	if errors.As(err, &httpErr) {
-		errors.As(httpErr.Internal, &httpErr)
-	} else {
-		log.Println(err)
-		httpErr = ErrInternalServerError
+	var debug *errorDebug
+	if c.app.Debug {
+		debug = newErrorDebug(err, httpErr)
 	}
 
-	err = c.JSON(httpErr, httpErr.Code)
+	switch c.Accepts(MIMEApplicationJSON, MIMETextHTML, MIMETextPlain) {
+	case MIMETextHTML:
+		err = renderHTML(c, httpErr, debug)
+	case MIMETextPlain:
+		err = renderText(c, httpErr, debug)
+	default:
+		err = renderJSON(c, httpErr, debug)
+	}
 
 	if err != nil {
-		log.Println(err)
+		c.Logger().Error("write error response", slog.Any("error", err))
+	}
+}
+
+// toHTTPError returns the HTTPError err wraps, or ErrInternalServerError for
+// other errors, which are logged.
+func toHTTPError(err error, c *Context) *HTTPError {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
 	}
+	c.Logger().Error("internal error", slog.Any("error", err))
+	return ErrInternalServerError
 }
 
 func handleNotFound(c *Context) error {
@@ -47,105 +62,207 @@ func handleNotFound(c *Context) error {
 }
 
 type App struct {
-	mux             *http.ServeMux
+	router          Router
+	pre             []HandlerFunc // ends with dispatch
 	middleware      []HandlerFunc
-	ErrorHandler    ErrHandlerFunc
+	routes          []*Route
+	named           map[string]*Route
+	ErrorHandler    ErrHandlerFunc // answers errors until the response is committed, see OnError
 	NotFoundHandler HandlerFunc
+	Validator       Validator          // validates bound values, see TagValidator
+	CookieKeys      [][]byte           // secrets of signed and encrypted cookies, newest first
+	RedirectHosts   []string           // hosts redirects may point to, like "*.example.com"
+	ListenerNetwork string             // network of Start and StartTLS: tcp (default), tcp4, tcp6 or unix
+	ShutdownTimeout time.Duration      // given to in-flight requests on SIGINT or SIGTERM, see Listener
+	ErrorTemplate   *template.Template // renders errors for HTML clients, see ErrorPage
+	Logger          *slog.Logger       // logger of the framework and Context.Logger, slog.Default() if nil
+	Debug           bool               // panic on misuse and show internal errors in responses
+	pool            sync.Pool
+	mu              sync.Mutex // guards server
+	server          *server
+	hooks           hooks
 }
 
-func New() *App {
-	return &App{
-		mux:             http.NewServeMux(),
+func New(opts ...Option) *App {
+	a := &App{
+		named:           make(map[string]*Route),
 		ErrorHandler:    handleError,
 		NotFoundHandler: handleNotFound,
 	}
+	a.router = newMuxRouter(a)
+	a.pre = []HandlerFunc{a.dispatch}
+	a.pool.New = func() any {
+		c := &Context{app: a, Res: &Response{}, Query: make(url.Values)}
+		c.Res.ctx = c
+		return c
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
-// Entry point for the request of the application.
-// It is responsible of creating the context and passing it to the middleware chain.
-// It also handles the response error and calls the error handler.
-//
-// It should be handled by the App.ServeHTTP method.
-// but for now this is the top most layer that i can handle it from passing the context
-// to the middleware and handling the error.
+// NewHandler adapts h to an http.Handler, running it with a pooled context
+// and passing any returned error to the error handler.
 func (a *App) NewHandler(h HandlerFunc) http.Handler {
+	chain := []HandlerFunc{h}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		c := &Context{
-			app:    a,
-			Req:    r,
-			Res:    NewResponse(w),
-			Query:  r.URL.Query(),
-			Header: w.Header(),
-			Next:   func() error { return nil },
-		}
-
-		if err := h(c); err != nil {
-			a.ErrorHandler(err, c)
-		}
+		a.serve(w, r, chain)
 	})
 }
 
-// Creates a chain of handlers to be executed in reverse order.
-func compose(handlers []HandlerFunc) HandlerFunc {
-	return func(c *Context) error {
-		i := len(handlers)
-		c.Next = func() error {
-			i--
-			if i < 0 {
-				return ErrNoNextHandler
-			}
-			return handlers[i](c)
-		}
+// serve runs the chain of handlers for the request. The context is taken
+// from the pool and returned to it once the chain and error handler are done.
+func (a *App) serve(w http.ResponseWriter, r *http.Request, chain []HandlerFunc) {
+	c := a.pool.Get().(*Context)
+	c.reset(w, r, chain)
 
-		return c.Next()
+	if err := c.Next(); err != nil {
+		a.catch(err, c)
 	}
+
+	c.reset(nil, nil, nil)
+	a.pool.Put(c)
 }
 
+// Pre registers h to run before the request is routed, so it can rewrite
+// the method, host or path used for matching. Unlike Use, it also runs for
+// requests that end up not matching any route.
+func (a *App) Pre(h HandlerFunc) {
+	a.pre = slices.Insert(a.pre, len(a.pre)-1, h)
+}
+
+// Use registers middleware running before the handlers of every route,
+// including the routes registered before it. Middleware and handlers run in
+// registration order: app, groups, then h followed by hs, see Add.
 func (a *App) Use(h HandlerFunc) {
 	a.middleware = append(a.middleware, h)
+	a.rebuild()
+}
+
+// rebuild updates the chain of every route after middleware is added.
+func (a *App) rebuild() {
+	for _, r := range a.routes {
+		r.build()
+	}
+}
+
+// Add registers the route for the method and path. Middleware and handlers
+// run in registration order: app, groups, then h followed by hs.
+func (a *App) Add(method, path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return a.add(method, path, nil, h, hs)
 }
 
-func (a *App) Add(method, path string, h HandlerFunc, hs ...HandlerFunc) {
-	chain := append(append(hs, h), a.middleware...)
-	h = compose(chain)
-	// addRoute(a.mux, method, FmtPath(path), h, a.ErrorHandler)
-	a.mux.Handle(Pattern(path, method), a.NewHandler(h))
+// add registers the route running the app middleware, then the middleware of
+// the group g, if any, and finally the route handlers.
+func (a *App) add(method, path string, g *Group, h HandlerFunc, hs []HandlerFunc) *Route {
+	segments, err := parsePath(path)
+	if err != nil {
+		panic("lit: " + err.Error())
+	}
+
+	r := &Route{
+		app:      a,
+		group:    g,
+		Method:   method,
+		Path:     path,
+		segments: segments,
+		handlers: append([]HandlerFunc{h}, hs...),
+	}
+	r.handler = funcName(r.handlers[len(r.handlers)-1])
+	r.build()
+
+	a.router.Add(method, FmtPath(muxPath(segments)), r)
+	a.addRoute(r)
+
+	return r
 }
 
-func (a *App) GET(path string, h HandlerFunc, hs ...HandlerFunc) {
-	a.Add(http.MethodGet, path, h, hs...)
+// Mount serves h under the prefix, stripping it from the request path. It
+// is typically used to compose independent apps, whose own middleware and
+// error handlers keep working as usual.
+func (a *App) Mount(prefix string, h http.Handler) {
+	a.mount(prefix, nil, h)
 }
 
-func (a *App) POST(path string, h HandlerFunc, hs ...HandlerFunc) {
-	a.Add(http.MethodPost, path, h, hs...)
+func (a *App) mount(prefix string, g *Group, h http.Handler) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	strip := stripPrefix(prefix, h)
+
+	r := &Route{
+		app:   a,
+		group: g,
+		Path:  prefix + "/",
+		handlers: []HandlerFunc{func(c *Context) error {
+			strip.ServeHTTP(c.Res, c.Req)
+			return nil
+		}},
+		handler: fmt.Sprintf("%T", h),
+	}
+	r.build()
+
+	if prefix != "" {
+		a.router.Add("", prefix, r)
+	}
+	a.router.Add("", r.Path, r)
+	a.addRoute(r)
 }
 
-func (a *App) PUT(path string, h HandlerFunc, hs ...HandlerFunc) {
-	a.Add(http.MethodPut, path, h, hs...)
+// stripPrefix is like http.StripPrefix but serves the prefix itself as "/".
+func stripPrefix(prefix string, h http.Handler) http.Handler {
+	if prefix == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = strings.TrimPrefix(r.URL.Path, prefix)
+		r2.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, prefix)
+		if r2.URL.Path == "" {
+			r2.URL.Path = "/"
+		}
+		h.ServeHTTP(w, r2)
+	})
 }
 
-func (a *App) PATCH(path string, h HandlerFunc, hs ...HandlerFunc) {
-	a.Add(http.MethodPatch, path, h, hs...)
+func (a *App) GET(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return a.Add(http.MethodGet, path, h, hs...)
 }
 
-func (a *App) DELETE(path string, h HandlerFunc, hs ...HandlerFunc) {
-	a.Add(http.MethodDelete, path, h, hs...)
+func (a *App) POST(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return a.Add(http.MethodPost, path, h, hs...)
 }
 
+func (a *App) PUT(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return a.Add(http.MethodPut, path, h, hs...)
+}
+
+func (a *App) PATCH(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return a.Add(http.MethodPatch, path, h, hs...)
+}
+
+func (a *App) DELETE(path string, h HandlerFunc, hs ...HandlerFunc) *Route {
+	return a.Add(http.MethodDelete, path, h, hs...)
+}
+
+// ServeHTTP runs the Pre handlers and dispatches the request to the
+// matching route. Unmatched requests still run the app middleware and end in
+// the NotFoundHandler, or in ErrMethodNotAllowed when the path exists under
+// other methods.
 func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	a.mux.ServeHTTP(w, r)
+	a.serve(w, r, a.pre)
+}
 
-	// there must be a way handle the logic here instead of in the mux
-	// c := &Context{
-	// 	Req:    r,
-	// 	Res:    NewResponse(w),
-	// 	Query:  r.URL.Query(),
-	// 	Header: w.Header(),
-	// 	Next:   func() error { return nil },
-	// 	app:    a,
-	// }
-	//
-	// _, pattern := a.mux.Handler(r)
+// dispatch ends the Pre handlers, running the chain of the route matching
+// the possibly rewritten request.
+func (a *App) dispatch(c *Context) error {
+	route, chain := a.match(c.Req)
+	c.route, c.logger = route, nil
+	return c.run(chain)
 }
 
 func Encode[T any](w http.ResponseWriter, r *http.Request, v T) error {
@@ -178,15 +295,23 @@ type Response struct {
 	Size     int64
 	Status   int
 	Commited bool
+	discard  bool     // counts but does not write the body
+	ctx      *Context // logs misuse, nil for NewResponse
 }
 
 func NewResponse(w http.ResponseWriter) *Response {
 	return &Response{ResponseWriter: w}
 }
 
+// reset prepares the response for w, discarding the body of HEAD requests,
+// which are routed to the GET handler of the path.
+func (r *Response) reset(w http.ResponseWriter, req *http.Request) {
+	*r = Response{ResponseWriter: w, discard: req != nil && req.Method == http.MethodHead, ctx: r.ctx}
+}
+
 func (r *Response) WriteHeader(code int) {
 	if r.Commited {
-		log.Println("response already committed")
+		r.logger().Warn("response already committed", slog.Int("status", code))
 		return
 	}
 
@@ -195,6 +320,13 @@ func (r *Response) WriteHeader(code int) {
 	r.Commited = true
 }
 
+func (r *Response) logger() *slog.Logger {
+	if r.ctx != nil {
+		return r.ctx.Logger()
+	}
+	return slog.Default()
+}
+
 func (r *Response) Write(b []byte) (int, error) {
 	if !r.Commited {
 		if r.Status == 0 {
@@ -203,6 +335,11 @@ func (r *Response) Write(b []byte) (int, error) {
 		r.WriteHeader(http.StatusOK)
 	}
 
+	if r.discard {
+		r.Size += int64(len(b))
+		return len(b), nil
+	}
+
 	n, err := r.ResponseWriter.Write(b)
 	r.Size += int64(n)
 	return n, err
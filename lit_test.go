@@ -93,6 +93,11 @@ func TestLitMW(t *testing.T) {
 	app := lit.New()
 	app.Use(middleware.Logging())
 
+	var handled error
+	app.OnError(func(err error, c *lit.Context) {
+		handled = err
+	})
+
 	app.GET("/",
 		func(c *lit.Context) error {
 			t.Log("mw1")
@@ -105,21 +110,94 @@ func TestLitMW(t *testing.T) {
 
 	app.GET("/next",
 		func(c *lit.Context) error {
-			c.Next() // should error here
-			return c.Next()
+			c.Next()
+			return c.Next() // should error here
 		},
 		func(c *lit.Context) error {
 			return c.Text("Hello World!")
 		})
 
+	app.GET("/end", func(c *lit.Context) error {
+		return c.Next()
+	})
+
 	t.Run("middleware", func(t *testing.T) {
 		w := makeRequest(app, "GET", "/", nil, false)
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "Hello World!", w.Body.String())
 	})
 
-	// t.Run("multiple next calls", func(t *testing.T) {
-	// 	res := makeRequest(app, "GET", "/next")
-	// 	assert.Equal(t, http.StatusInternalServerError, res.Code)
-	// })
+	t.Run("multiple next calls", func(t *testing.T) {
+		handled = nil
+		w := makeRequest(app, "GET", "/next", nil, false)
+
+		assert.Equal(t, "Hello World!", w.Body.String())
+		assert.ErrorIs(t, handled, lit.ErrNextCalledTwice)
+		assert.Contains(t, handled.Error(), "TestLitMW.func4")
+	})
+
+	t.Run("next at end of chain", func(t *testing.T) {
+		handled = nil
+		w := makeRequest(app, "GET", "/end", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NoError(t, handled)
+	})
+
+	t.Run("debug panics", func(t *testing.T) {
+		app.Debug = true
+		defer func() { app.Debug = false }()
+
+		assert.PanicsWithError(t, "next called more than once: github.com/jocades/lit_test.TestLitMW.func4", func() {
+			makeRequest(app, "GET", "/next", nil, false)
+		})
+	})
+}
+
+func TestHandlerOrder(t *testing.T) {
+	app := lit.New()
+	app.Use(trace("use1"))
+	app.Use(trace("use2"))
+
+	app.GET("/", trace("h"), trace("a"), func(c *lit.Context) error {
+		c.Header.Add("X-Trace", "b")
+		return nil
+	})
+
+	w := makeRequest(app, "GET", "/", nil, false)
+	assert.Equal(t, []string{"use1", "use2", "h", "a", "b"}, w.Header().Values("X-Trace"))
+}
+
+func TestContextReuse(t *testing.T) {
+	app := lit.New()
+	app.GET("/query", func(c *lit.Context) error {
+		return c.JSON(c.Query)
+	})
+
+	w := makeRequest(app, "GET", "/query?a=1&b=2&b=3", nil, false)
+	body, _ := decode[map[string][]string](w.Body)
+	assert.Equal(t, map[string][]string{"a": {"1"}, "b": {"2", "3"}}, body)
+
+	w = makeRequest(app, "GET", "/query?c=%20x", nil, false)
+	body, _ = decode[map[string][]string](w.Body)
+	assert.Equal(t, map[string][]string{"c": {" x"}}, body)
+}
+
+func BenchmarkMiddleware(b *testing.B) {
+	app := lit.New(lit.WithRouter(lit.NewTree()))
+	for range 5 {
+		app.Use(func(c *lit.Context) error { return c.Next() })
+	}
+	app.GET("/", func(c *lit.Context) error {
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("GET", "/?page=1", nil)
+	w := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		app.ServeHTTP(w, req)
+	}
 }
@@ -0,0 +1,84 @@
+package lit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/jocades/lit/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger(t *testing.T) {
+	var logs bytes.Buffer
+	app := lit.New()
+	app.Logger = slog.New(slog.NewJSONHandler(&logs, nil))
+
+	app.Pre(func(c *lit.Context) error {
+		c.Logger().Info("pre")
+		return c.Next()
+	})
+	app.Group("/api").GET("/users/{id}", func(c *lit.Context) error {
+		c.Logger().Info("show", "id", c.Param("id"))
+		return c.Text("ok")
+	})
+
+	records := func() []lit.Map {
+		var rs []lit.Map
+		dec := json.NewDecoder(&logs)
+		for dec.More() {
+			var r lit.Map
+			assert.NoError(t, dec.Decode(&r))
+			delete(r, "time")
+			rs = append(rs, r)
+		}
+		return rs
+	}
+
+	t.Run("request id header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/users/7", nil)
+		req.Header.Set(lit.HeaderXRequestID, "abc")
+		app.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, []lit.Map{
+			{"level": "INFO", "msg": "pre", "request_id": "abc", "method": "GET", "path": "/api/users/7"},
+			{"level": "INFO", "msg": "show", "request_id": "abc", "method": "GET", "path": "/api/users/7", "route": "/api/users/{id}", "id": "7"},
+		}, records())
+	})
+
+	t.Run("no request id", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/api/users/7", nil, false)
+
+		assert.Empty(t, w.Header().Get(lit.HeaderXRequestID))
+		for _, r := range records() {
+			assert.NotContains(t, r, "request_id")
+		}
+	})
+
+	t.Run("generated request id", func(t *testing.T) {
+		app := lit.New()
+		app.Logger = slog.New(slog.NewJSONHandler(&logs, nil))
+		app.Pre(middleware.RequestID())
+		app.Pre(func(c *lit.Context) error {
+			c.Logger().Info("pre")
+			return c.Next()
+		})
+		app.GET("/", func(c *lit.Context) error {
+			c.Logger().Info("index")
+			return nil
+		})
+
+		w := makeRequest(app, "GET", "/", nil, false)
+
+		id := w.Header().Get(lit.HeaderXRequestID)
+		assert.Len(t, id, 16)
+		rs := records()
+		assert.Len(t, rs, 2)
+		for _, r := range rs {
+			assert.Equal(t, id, r["request_id"])
+		}
+	})
+}
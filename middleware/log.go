@@ -1,30 +1,40 @@
 package middleware
 
 import (
-	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/jocades/lit"
-	"github.com/jocades/lit/color"
 )
 
+// Logging logs every request with Context.Logger once it is served, along
+// with its status, size and duration. Errors of the rest of the chain are
+// handled first with Context.Error so the final status is logged, which
+// means handlers before Logging do not see them. Server error statuses are
+// logged at the error level and other errors at the warning level.
 func Logging() lit.HandlerFunc {
 	return func(c *lit.Context) error {
 		start := time.Now()
-		m := color.Magenta(c.Req.Method)
-		u := color.Cyan(c.Req.URL.String())
-
-		fmt.Printf("-> %s %s\n", m, u)
 		err := c.Next()
-
-		fmt.Println("after next", err, c.Res)
-		status := c.Res.Status
-		elapsed := time.Since(start).String()
-
-		fmt.Printf("<- %s %s\n", m, u)
-		fmt.Printf("   %s %s\n", lit.FmtStatus(status), lit.FmtColor(elapsed, "blue"))
-
-		return err
+		if err != nil {
+			c.Error(err)
+		}
+
+		attrs := []slog.Attr{
+			slog.Int("status", c.Res.Status),
+			slog.Int64("size", c.Res.Size),
+			slog.Duration("duration", time.Since(start)),
+		}
+		level := slog.LevelInfo
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+			level = slog.LevelWarn
+		}
+		if c.Res.Status >= 500 {
+			level = slog.LevelError
+		}
+
+		c.Logger().LogAttrs(c.Req.Context(), level, "request", attrs...)
+		return nil
 	}
-
 }
@@ -0,0 +1,58 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/jocades/lit/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogging(t *testing.T) {
+	var logs bytes.Buffer
+	app := lit.New()
+	app.Logger = slog.New(slog.NewJSONHandler(&logs, nil))
+	app.Use(middleware.Logging())
+
+	app.GET("/ok", func(c *lit.Context) error { return c.Text("hello") })
+	app.GET("/fail", func(c *lit.Context) error { return errors.New("boom") })
+
+	cases := []struct {
+		path   string
+		level  string
+		status float64
+		err    any
+	}{
+		{"/ok", "INFO", http.StatusOK, nil},
+		{"/fail", "ERROR", http.StatusInternalServerError, "boom"},
+		{"/missing", "WARN", http.StatusNotFound, "code: 404, message: Not Found"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			logs.Reset()
+			app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", tc.path, nil))
+
+			var r map[string]any
+			dec := json.NewDecoder(&logs)
+			for r["msg"] != "request" {
+				r = nil
+				if !assert.NoError(t, dec.Decode(&r)) {
+					return
+				}
+			}
+			assert.Equal(t, "request", r["msg"])
+			assert.Equal(t, tc.level, r["level"])
+			assert.Equal(t, tc.path, r["path"])
+			assert.Equal(t, tc.status, r["status"])
+			assert.Equal(t, tc.err, r["error"])
+			assert.Contains(t, r, "duration")
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/jocades/lit"
+)
+
+// MethodOverride lets POST requests pick their method with the
+// X-HTTP-Method-Override header, for clients limited to GET and POST. It must
+// be registered with App.Pre for the new method to be used in routing.
+func MethodOverride() lit.HandlerFunc {
+	return func(c *lit.Context) error {
+		if c.Req.Method == http.MethodPost {
+			if m := c.Req.Header.Get(lit.HeaderXHTTPMethodOverride); m != "" {
+				c.Req.Method = strings.ToUpper(m)
+			}
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,11 @@
+package middleware
+
+import "github.com/jocades/lit"
+
+// Recover recovers from panics in the handlers after it, passing them to the
+// report functions before the ErrorHandler, see lit.Recover. Register it
+// first with App.Use to cover every route, or use lit.WithRecover to cover
+// the Pre handlers and unmatched requests too.
+func Recover(report ...lit.ErrHandlerFunc) lit.HandlerFunc {
+	return lit.Recover(report...)
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/jocades/lit"
+)
+
+// RequestID gives every request an ID, keeping the X-Request-Id header sent
+// by the client or generating one, and sets it on the response. The ID is
+// part of Context.Logger, register RequestID with App.Pre so it is for every
+// log line of the request.
+func RequestID() lit.HandlerFunc {
+	return func(c *lit.Context) error {
+		id := c.Req.Header.Get(lit.HeaderXRequestID)
+		if id == "" {
+			id = fmt.Sprintf("%016x", rand.Uint64())
+			c.Req.Header.Set(lit.HeaderXRequestID, id)
+		}
+		c.Header.Set(lit.HeaderXRequestID, id)
+		return c.Next()
+	}
+}
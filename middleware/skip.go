@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jocades/lit"
+)
+
+// Skipper reports whether a middleware should be skipped for the request.
+// Predicates of When and the matchers below share the same signature.
+type Skipper func(c *lit.Context) bool
+
+// Skip runs mw unless skip reports true for the request.
+func Skip(skip Skipper, mw lit.HandlerFunc) lit.HandlerFunc {
+	return func(c *lit.Context) error {
+		if skip(c) {
+			return c.Next()
+		}
+		return mw(c)
+	}
+}
+
+// When runs mw only for requests matching pred.
+func When(pred func(c *lit.Context) bool, mw lit.HandlerFunc) lit.HandlerFunc {
+	return Skip(Not(pred), mw)
+}
+
+// Unless runs mw except for requests under one of the path prefixes.
+//
+//	app.Use(middleware.Unless(middleware.Logging(), "/healthz", "/public/*"))
+func Unless(mw lit.HandlerFunc, prefixes ...string) lit.HandlerFunc {
+	return Skip(PathPrefix(prefixes...), mw)
+}
+
+// ForMethods runs mw only for requests with one of the methods.
+func ForMethods(mw lit.HandlerFunc, methods ...string) lit.HandlerFunc {
+	return When(Method(methods...), mw)
+}
+
+// PathPrefix matches requests whose path is one of the prefixes or lies
+// under it. A trailing "/*" in a prefix is optional.
+func PathPrefix(prefixes ...string) Skipper {
+	trimmed := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		trimmed[i] = strings.TrimSuffix(strings.TrimSuffix(p, "*"), "/")
+	}
+	return func(c *lit.Context) bool {
+		path := c.Path()
+		for _, p := range trimmed {
+			if path == p || strings.HasPrefix(path, p+"/") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Method matches requests with one of the methods.
+func Method(methods ...string) Skipper {
+	return func(c *lit.Context) bool {
+		return slices.Contains(methods, c.Req.Method)
+	}
+}
+
+// Not negates the predicate.
+func Not(pred func(c *lit.Context) bool) Skipper {
+	return func(c *lit.Context) bool {
+		return !pred(c)
+	}
+}
@@ -0,0 +1,61 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/jocades/lit/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func mark(name string) lit.HandlerFunc {
+	return func(c *lit.Context) error {
+		c.Header.Add("X-Mark", name)
+		return c.Next()
+	}
+}
+
+func TestSkip(t *testing.T) {
+	app := lit.New()
+	app.Use(middleware.Unless(mark("auth"), "/public/*", "/healthz"))
+	app.Use(middleware.ForMethods(mark("write"), http.MethodPost, http.MethodPut))
+	app.Use(middleware.When(func(c *lit.Context) bool {
+		return c.Req.Header.Get("X-Debug") != ""
+	}, mark("debug")))
+
+	ok := func(c *lit.Context) error { return c.Text("ok") }
+	app.GET("/healthz", ok)
+	app.GET("/healthzz", ok)
+	app.GET("/public/{file}", ok)
+	app.GET("/users", ok)
+	app.POST("/users", ok)
+
+	cases := []struct {
+		method, path string
+		debug        bool
+		marks        []string
+	}{
+		{"GET", "/healthz", false, nil},
+		{"GET", "/healthzz", false, []string{"auth"}},
+		{"GET", "/public/app.js", false, nil},
+		{"GET", "/users", false, []string{"auth"}},
+		{"POST", "/users", false, []string{"auth", "write"}},
+		{"GET", "/users", true, []string{"auth", "debug"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.debug {
+				req.Header.Set("X-Debug", "1")
+			}
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.marks, w.Header().Values("X-Mark"))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package lit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMount(t *testing.T) {
+	app := lit.New()
+	app.Use(trace("app"))
+
+	users := lit.New()
+	users.Use(trace("users"))
+	users.ErrorHandler = func(err error, c *lit.Context) {
+		c.Text("users: "+err.Error(), http.StatusTeapot)
+	}
+
+	users.GET("/", func(c *lit.Context) error {
+		return c.Text("list")
+	})
+
+	users.GET("/{id}", func(c *lit.Context) error {
+		return c.Text("user " + c.Param("id"))
+	})
+
+	users.GET("/fail", func(c *lit.Context) error {
+		return lit.ErrBadRequest
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/path", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.URL.Path)
+	})
+
+	app.Mount("/users", users)
+	app.Group("/admin").Mount("/mux/", mux)
+
+	t.Run("mounted app", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/users/1", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "user 1", w.Body.String())
+		assert.Equal(t, []string{"app", "users"}, w.Header().Values("X-Trace"))
+	})
+
+	t.Run("mounted app root", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/users", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "list", w.Body.String())
+	})
+
+	t.Run("mounted app error handler", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/users/fail", nil, false)
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+		assert.Contains(t, w.Body.String(), "users: ")
+	})
+
+	t.Run("mounted handler", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/admin/mux/path", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "/path", w.Body.String())
+	})
+}
@@ -0,0 +1,192 @@
+package lit
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Constraints are the named constraints available to path wildcards, as in
+// "/users/{id:int}". Any other constraint is compiled as a regular
+// expression which must match the whole value.
+var Constraints = map[string]string{
+	"int":   `-?[0-9]+`,
+	"uint":  `[0-9]+`,
+	"alpha": `[a-zA-Z]+`,
+	"alnum": `[a-zA-Z0-9]+`,
+	"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+}
+
+var uuidRe = regexp.MustCompile("^" + Constraints["uuid"] + "$")
+
+// segment is either literal text of a route path or a wildcard.
+type segment struct {
+	text  string
+	param *param
+}
+
+// param is a path wildcard, possibly constrained.
+type param struct {
+	name string
+	rest bool           // matches the remainder of the path, {name...}
+	re   *regexp.Regexp // constraint, if any
+}
+
+func (p *param) String() string {
+	if p.rest {
+		return "{" + p.name + "...}"
+	}
+	return "{" + p.name + "}"
+}
+
+// parsePath splits the path into literal text and wildcards, allowing
+// braces inside the constraints of wildcards.
+func parsePath(path string) ([]segment, error) {
+	var segments []segment
+	for len(path) > 0 {
+		i := strings.IndexByte(path, '{')
+		if i < 0 {
+			segments = append(segments, segment{text: path})
+			break
+		}
+		if i > 0 {
+			segments = append(segments, segment{text: path[:i]})
+		}
+
+		depth, j := 0, i
+		for ; j < len(path); j++ {
+			if path[j] == '{' {
+				depth++
+			} else if path[j] == '}' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if depth != 0 {
+			return nil, fmt.Errorf("unclosed wildcard in %q", path)
+		}
+
+		p, err := parseParam(path[i+1 : j])
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment{param: p})
+		path = path[j+1:]
+	}
+	return segments, nil
+}
+
+func parseParam(s string) (*param, error) {
+	name, constraint, ok := strings.Cut(s, ":")
+	p := &param{name: name}
+	if n, ok := strings.CutSuffix(name, "..."); ok {
+		p.name, p.rest = n, true
+	}
+	if !ok {
+		return p, nil
+	}
+
+	expr, ok := Constraints[constraint]
+	if !ok {
+		expr = constraint
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("wildcard %q: %w", name, err)
+	}
+	p.re = re
+	return p, nil
+}
+
+// muxPath returns the path without constraints, as understood by the mux.
+func muxPath(segments []segment) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if s.param != nil {
+			b.WriteString(s.param.String())
+		} else {
+			b.WriteString(s.text)
+		}
+	}
+	return b.String()
+}
+
+// ParamType lists the types path values can be parsed into.
+type ParamType interface {
+	~string | ~bool |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Param parses the path value of the wildcard name into T. A value that
+// does not parse results in an ErrBadRequest based *HTTPError.
+//
+//	id, err := lit.Param[int64](c, "id")
+func Param[T ParamType](c *Context, name string) (T, error) {
+	var v T
+	s := c.Param(name)
+	if err := setValue(reflect.ValueOf(&v).Elem(), s); err != nil {
+		return v, paramError(name, s, reflect.TypeOf(v).Kind().String(), err)
+	}
+	return v, nil
+}
+
+// ParamInt parses the path value of the wildcard name as an int.
+func (c *Context) ParamInt(name string) (int, error) {
+	return Param[int](c, name)
+}
+
+// ParamUUID returns the path value of the wildcard name, checking it is a
+// UUID in its canonical textual form.
+func (c *Context) ParamUUID(name string) (string, error) {
+	s := c.Param(name)
+	if !uuidRe.MatchString(s) {
+		return "", paramError(name, s, "uuid", nil)
+	}
+	return s, nil
+}
+
+func paramError(name, value, kind string, err error) *HTTPError {
+	msg := fmt.Sprintf("invalid path param %q: %q is not a valid %s", name, value, kind)
+	return ErrBadRequest.WithMessage(msg).WithInternal(err)
+}
+
+// setValue parses s into v according to its kind.
+func setValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
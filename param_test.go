@@ -0,0 +1,142 @@
+package lit_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamConstraints(t *testing.T) {
+	app := lit.New()
+
+	app.GET("/users/{id:int}", func(c *lit.Context) error {
+		id, err := c.ParamInt("id")
+		if err != nil {
+			return err
+		}
+		return c.JSON(lit.Map{"id": id})
+	}).Name("user")
+
+	app.GET("/posts/{slug:[a-z-]+}", func(c *lit.Context) error {
+		return c.Text(c.Param("slug"))
+	})
+
+	app.GET("/codes/{code:[0-9]{3}}", func(c *lit.Context) error {
+		return c.Text(c.Param("code"))
+	})
+
+	app.GET("/orders/{uuid:uuid}", func(c *lit.Context) error {
+		id, err := c.ParamUUID("uuid")
+		if err != nil {
+			return err
+		}
+		return c.Text(id)
+	})
+
+	app.GET("/pages/{n}", func(c *lit.Context) error {
+		n, err := lit.Param[uint8](c, "n")
+		if err != nil {
+			return err
+		}
+		return c.JSON(lit.Map{"page": n})
+	})
+
+	cases := []struct {
+		path string
+		code int
+	}{
+		{"/users/42", http.StatusOK},
+		{"/users/abc", http.StatusNotFound},
+		{"/posts/hello-world", http.StatusOK},
+		{"/posts/Hello", http.StatusNotFound},
+		{"/codes/404", http.StatusOK},
+		{"/codes/4040", http.StatusNotFound},
+		{"/orders/0b7c5c2e-6f4a-4f0e-9a57-2a1f3c1d9e10", http.StatusOK},
+		{"/orders/123", http.StatusNotFound},
+		{"/pages/3", http.StatusOK},
+		{"/pages/300", http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			w := makeRequest(app, "GET", tc.path, nil, false)
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+
+	t.Run("bad request message", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/pages/x", nil, false)
+		body, _ := decode[lit.Map](w.Body)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, `invalid path param "n": "x" is not a valid uint8`, body["message"])
+	})
+
+	t.Run("url checks constraints", func(t *testing.T) {
+		u, err := app.URL("user", "id", 7)
+		assert.NoError(t, err)
+		assert.Equal(t, "/users/7", u)
+
+		_, err = app.URL("user", "id", "seven")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid constraint", func(t *testing.T) {
+		assert.Panics(t, func() {
+			app.GET("/bad/{id:[}", func(c *lit.Context) error { return nil })
+		})
+	})
+}
+
+func TestParamError(t *testing.T) {
+	app := lit.New()
+	var err error
+	app.GET("/{id}", func(c *lit.Context) error {
+		_, err = c.ParamInt("id")
+		return err
+	})
+
+	makeRequest(app, "GET", "/x", nil, false)
+
+	var httpErr *lit.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Error(t, httpErr.Internal)
+}
+
+func TestParamConstraintsMethods(t *testing.T) {
+	for name, newApp := range routers() {
+		app := newApp()
+		app.GET("/users/{id:int}", func(c *lit.Context) error { return c.Text("user") })
+		app.DELETE("/users/{name:alpha}", func(c *lit.Context) error { return c.Text("deleted") })
+
+		cases := []struct {
+			method, path string
+			code         int
+			allow        string
+		}{
+			{"DELETE", "/users/42", http.StatusMethodNotAllowed, "GET, HEAD, OPTIONS"},
+			{"POST", "/users/42", http.StatusMethodNotAllowed, "GET, HEAD, OPTIONS"},
+			{"OPTIONS", "/users/42", http.StatusNoContent, "GET, HEAD, OPTIONS"},
+			{"GET", "/users/abc", http.StatusMethodNotAllowed, "DELETE, OPTIONS"},
+			{"GET", "/users/a1", http.StatusNotFound, ""},
+			{"DELETE", "/users/a1", http.StatusNotFound, ""},
+			{"POST", "/users/abc", http.StatusMethodNotAllowed, "DELETE, OPTIONS"},
+			{"OPTIONS", "/users/abc", http.StatusNoContent, "DELETE, OPTIONS"},
+			{"POST", "/users/a1", http.StatusNotFound, ""},
+			{"OPTIONS", "/users/a1", http.StatusNotFound, ""},
+		}
+
+		for _, tc := range cases {
+			t.Run(name+" "+tc.method+" "+tc.path, func(t *testing.T) {
+				w := makeRequest(app, tc.method, tc.path, nil, false)
+
+				assert.Equal(t, tc.code, w.Code)
+				assert.Equal(t, tc.allow, w.Header().Get(lit.HeaderAllow))
+			})
+		}
+	}
+}
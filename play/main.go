@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/jocades/lit"
+	litfmt "github.com/jocades/lit/fmt"
 	mw "github.com/jocades/lit/middleware"
 )
 
@@ -21,7 +22,6 @@ func main() {
 	app.GET("/",
 		func(c *lit.Context) error {
 			fmt.Println("mw1")
-			c.Next()
 			return c.Next()
 		},
 		func(c *lit.Context) error {
@@ -33,17 +33,8 @@ func main() {
 		return c.NotFound()
 	})
 
-	fmt.Println("Listening...")
-
-	mux := http.NewServeMux()
 	sub := http.NewServeMux()
 
-	// h := func(res http.ResponseWriter, r *http.Request) {
-	// 	res.Write([]byte("Hello World!"))
-	// }
-
-	// hf := http.HandlerFunc(h)
-
 	sub.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, "root")
 	})
@@ -52,10 +43,19 @@ func main() {
 		fmt.Fprintln(w, "sub")
 	})
 
-	mux.Handle("/", sub)
+	app.Mount("/mux", sub)
+
+	users := lit.New()
+	users.GET("/{id}", func(c *lit.Context) error {
+		return c.JSON(lit.Map{"id": c.Param("id")})
+	})
+
+	app.Mount("/users", users)
 
-	if err := http.ListenAndServe(":8000", app); err != nil {
+	litfmt.PrintRoutes(app)
+	fmt.Println("Listening...")
+
+	if err := app.Start(":8000"); err != nil {
 		log.Fatal(err)
 	}
-
 }
@@ -0,0 +1,94 @@
+package lit
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Problem is an RFC 9457 problem details object, as written by
+// ProblemDetails.
+type Problem struct {
+	Type       string `json:"type,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Status     int    `json:"status,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+	Instance   string `json:"instance,omitempty"`
+	Extensions Map    `json:"-"` // members added next to the standard ones
+}
+
+// MarshalJSON writes the extension members at the top level of the object.
+// They cannot override the standard members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	b, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return b, err
+	}
+
+	members := make(map[string]any, len(p.Extensions))
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	if err := json.Unmarshal(b, &members); err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
+
+// NewProblem describes err as a Problem for the request of c. Errors other
+// than an HTTPError are described as ErrInternalServerError.
+//
+// The type is the one of the HTTPError, or "about:blank". Its Details become
+// extension members, and so does its ErrorCode, named "code". A string
+// message other than the status text is the detail. Any other message, like
+// the FieldError list of Bind, is the "errors" member.
+func NewProblem(err error, c *Context) Problem {
+	httpErr := toHTTPError(err, c)
+
+	p := Problem{
+		Type:     httpErr.Type,
+		Title:    http.StatusText(httpErr.Code),
+		Status:   httpErr.Code,
+		Instance: c.Req.URL.Path,
+	}
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+
+	if len(httpErr.Details) > 0 || httpErr.ErrorCode != "" {
+		p.Extensions = make(Map, len(httpErr.Details)+2)
+		for k, v := range httpErr.Details {
+			p.Extensions[k] = v
+		}
+		if httpErr.ErrorCode != "" {
+			p.Extensions["code"] = httpErr.ErrorCode
+		}
+	}
+	switch msg := httpErr.Message.(type) {
+	case nil:
+	case string:
+		if msg != p.Title {
+			p.Detail = msg
+		}
+	default:
+		if p.Extensions == nil {
+			p.Extensions = make(Map, 1)
+		}
+		p.Extensions["errors"] = msg
+	}
+	return p
+}
+
+// ProblemDetails is an ErrHandlerFunc writing errors as
+// application/problem+json, see NewProblem. Set it as the App.ErrorHandler
+// in place of the default, which writes {"message": ...}.
+func ProblemDetails(err error, c *Context) {
+	p := NewProblem(err, c)
+
+	c.Header.Set(HeaderContentType, MIMEApplicationProblem)
+	c.Res.WriteHeader(p.Status)
+	if err := Encode(c.Res, c.Req, p); err != nil {
+		c.Logger().Error("write error response", slog.Any("error", err))
+	}
+}
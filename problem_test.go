@@ -0,0 +1,93 @@
+package lit_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemDetails(t *testing.T) {
+	app := lit.New()
+	app.ErrorHandler = lit.ProblemDetails
+
+	app.GET("/users/{id}", func(c *lit.Context) error {
+		return lit.ErrNotFound.
+			WithMessage("user " + c.Param("id") + " does not exist").
+			WithType("https://example.com/probs/user-not-found").
+			WithCode("USER_NOT_FOUND").
+			WithDetails(lit.Map{"id": c.Param("id"), "status": "ignored"})
+	})
+	app.GET("/bind", func(c *lit.Context) error {
+		_, err := lit.Bind[struct {
+			Page int `query:"page"`
+		}](c)
+		return err
+	})
+	app.GET("/fail", func(c *lit.Context) error {
+		return errors.New("db is down")
+	})
+
+	cases := []struct {
+		name string
+		path string
+		want lit.Map
+	}{
+		{"http error", "/users/7", lit.Map{
+			"type":     "https://example.com/probs/user-not-found",
+			"title":    "Not Found",
+			"status":   float64(404),
+			"detail":   "user 7 does not exist",
+			"instance": "/users/7",
+			"id":       "7",
+			"code":     "USER_NOT_FOUND",
+		}},
+		{"errors member", "/bind?page=x", lit.Map{
+			"type":     "about:blank",
+			"title":    "Bad Request",
+			"status":   float64(400),
+			"instance": "/bind",
+			"errors": []any{map[string]any{
+				"field":   "page",
+				"source":  "query",
+				"message": `"x" is not a valid int`,
+			}},
+		}},
+		{"internal", "/fail", lit.Map{
+			"type":     "about:blank",
+			"title":    "Internal Server Error",
+			"status":   float64(500),
+			"instance": "/fail",
+		}},
+		{"not found", "/missing", lit.Map{
+			"type":     "about:blank",
+			"title":    "Not Found",
+			"status":   float64(404),
+			"instance": "/missing",
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := makeRequest(app, "GET", tc.path, nil, false)
+
+			assert.Equal(t, int(tc.want["status"].(float64)), w.Code)
+			assert.Equal(t, lit.MIMEApplicationProblem, w.Header().Get(lit.HeaderContentType))
+
+			got, err := decode[lit.Map](w.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("default shape", func(t *testing.T) {
+		app := lit.New()
+		app.GET("/", func(c *lit.Context) error {
+			return lit.ErrConflict.WithMessage("taken").WithType("https://example.com/probs/taken")
+		})
+
+		w := makeRequest(app, "GET", "/", nil, false)
+		assert.JSONEq(t, `{"message":"taken"}`, w.Body.String())
+	})
+}
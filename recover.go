@@ -0,0 +1,67 @@
+package lit
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"slices"
+)
+
+// PanicError is the internal error of the HTTPError a recovered panic results
+// in, see Recover.
+type PanicError struct {
+	Value any    // value passed to panic
+	Stack []byte // stack of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic when it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover recovers from panics in the rest of the chain, returning them as an
+// ErrInternalServerError whose internal error is a *PanicError, after passing
+// it to the report functions. Panics with http.ErrAbortHandler are left to
+// net/http.
+//
+// See WithRecover to recover from panics in every handler, including the Pre
+// ones, and middleware.Recover.
+func Recover(report ...ErrHandlerFunc) HandlerFunc {
+	return func(c *Context) (err error) {
+		// the handlers which panicked did not get to restore the context
+		handlers, called := c.handlers, c.called
+		res, req, header := c.Res, c.Req, c.Header
+
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			c.handlers, c.called = handlers, called
+			c.Res, c.Req, c.Header = res, req, header
+
+			p := &PanicError{Value: v, Stack: debug.Stack()}
+			e := ErrInternalServerError.WithInternal(p)
+			for _, r := range report {
+				r(e, c)
+			}
+			err = e
+		}()
+		return c.Next()
+	}
+}
+
+// WithRecover makes the app recover from panics in any handler, see Recover.
+func WithRecover(report ...ErrHandlerFunc) Option {
+	return func(a *App) {
+		a.pre = slices.Insert(a.pre, 0, Recover(report...))
+	}
+}
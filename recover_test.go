@@ -0,0 +1,94 @@
+package lit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/jocades/lit/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecover(t *testing.T) {
+	var reported []error
+	report := func(err error, c *lit.Context) {
+		reported = append(reported, err)
+	}
+
+	app := lit.New()
+	app.Use(middleware.Recover(report))
+
+	var handled error
+	app.ErrorHandler = func(err error, c *lit.Context) {
+		handled = err
+		c.JSON(lit.Map{"message": "oops"}, http.StatusInternalServerError)
+	}
+
+	boom := errors.New("boom")
+	app.GET("/panic", func(c *lit.Context) error {
+		panic(boom)
+	})
+	app.GET("/committed", func(c *lit.Context) error {
+		c.Text("partial")
+		panic("late")
+	})
+	app.GET("/ok", func(c *lit.Context) error {
+		return c.Text("ok")
+	})
+
+	w := makeRequest(app, "GET", "/panic", nil, false)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var httpErr *lit.HTTPError
+	assert.ErrorAs(t, handled, &httpErr)
+	assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+
+	var panicErr *lit.PanicError
+	assert.ErrorAs(t, httpErr.Internal, &panicErr)
+	assert.Equal(t, boom, panicErr.Value)
+	assert.ErrorIs(t, panicErr, boom)
+	assert.Contains(t, string(panicErr.Stack), "recover_test.go")
+	assert.Equal(t, []error{handled}, reported)
+
+	handled = nil
+	w = makeRequest(app, "GET", "/committed", nil, false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+	assert.Nil(t, handled)
+	assert.Len(t, reported, 2)
+
+	w = makeRequest(app, "GET", "/ok", nil, false)
+	assert.Equal(t, "ok", w.Body.String())
+}
+
+func TestWithRecover(t *testing.T) {
+	app := lit.New(lit.WithRecover())
+	app.Pre(func(c *lit.Context) error {
+		if c.Req.Header.Get("X-Panic") != "" {
+			panic("pre")
+		}
+		return c.Next()
+	})
+	app.Use(func(c *lit.Context) error {
+		c.Header.Set("X-Middleware", "yes")
+		return c.Next()
+	})
+	app.GET("/panic", func(c *lit.Context) error {
+		panic("route")
+	})
+
+	w := makeRequest(app, "GET", "/panic", nil, false)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "yes", w.Header().Get("X-Middleware"))
+
+	req, _ := http.NewRequest("GET", "/missing", nil)
+	req.Header.Set("X-Panic", "1")
+	w = httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = makeRequest(app, "GET", "/missing", nil, false)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
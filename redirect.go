@@ -0,0 +1,84 @@
+package lit
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redirect redirects the request to url with the code, 302 Found by
+// default. Only 3xx codes up to 308 are accepted, others result in
+// ErrInvalidRedirectCode. To prevent open redirects, absolute URLs must
+// point to the host of the request or one of App.RedirectHosts, otherwise
+// ErrUnsafeRedirect is returned.
+func (c *Context) Redirect(url string, code ...int) error {
+	status := http.StatusFound
+	if len(code) > 0 {
+		status = code[0]
+	}
+	if status < http.StatusMultipleChoices || status > http.StatusPermanentRedirect {
+		return ErrInvalidRedirectCode
+	}
+	if !c.safeRedirect(url) {
+		return ErrUnsafeRedirect
+	}
+
+	http.Redirect(c.Res, c.Req, url, status)
+	return nil
+}
+
+// RedirectToRoute redirects the request to the named route, see Route.URL
+// for the params.
+func (c *Context) RedirectToRoute(name string, params ...any) error {
+	url, err := c.URLFor(name, params...)
+	if err != nil {
+		return err
+	}
+	return c.Redirect(url)
+}
+
+// RedirectBack redirects the request to its Referer, or to fallback when
+// there is none or it is not safe to redirect to.
+func (c *Context) RedirectBack(fallback string) error {
+	if ref := c.Req.Referer(); ref != "" && c.safeRedirect(ref) {
+		return c.Redirect(ref)
+	}
+	return c.Redirect(fallback)
+}
+
+// safeRedirect reports whether target is relative or points to an allowed
+// host: the one of the request or one of App.RedirectHosts.
+func (c *Context) safeRedirect(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" && u.Host == "" {
+		// browsers read "/\host" like the protocol relative "//host", and
+		// http.Redirect prefixes targets like "\/host" with a slash
+		return !strings.HasPrefix(target, "//") && !strings.HasPrefix(target, `/\`) &&
+			!strings.HasPrefix(target, `\`)
+	}
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+
+	host, self := u.Hostname(), c.Req.Host
+	if h, _, err := net.SplitHostPort(self); err == nil {
+		self = h
+	}
+	if host == self {
+		return true
+	}
+	for _, allowed := range c.app.RedirectHosts {
+		if host == allowed {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*."); ok && strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirect(t *testing.T) {
+	app := lit.New()
+	app.RedirectHosts = []string{"auth.example.com", "*.cdn.io"}
+
+	app.GET("/users/{id}", func(c *lit.Context) error { return nil }).Name("user")
+
+	app.GET("/to", func(c *lit.Context) error {
+		if code := c.Query.Get("code"); code == "200" {
+			return c.Redirect(c.Query.Get("url"), http.StatusOK)
+		}
+		return c.Redirect(c.Query.Get("url"))
+	})
+
+	app.GET("/route", func(c *lit.Context) error {
+		return c.RedirectToRoute("user", "id", 7)
+	})
+
+	app.GET("/back", func(c *lit.Context) error {
+		return c.RedirectBack("/home")
+	})
+
+	cases := []struct {
+		url  string
+		code int
+	}{
+		{"/home", http.StatusFound},
+		{"http://example.com/x", http.StatusFound},
+		{"https://auth.example.com/login", http.StatusFound},
+		{"https://img.cdn.io/a.png", http.StatusFound},
+		{"https://evil.com", http.StatusInternalServerError},
+		{"//evil.com", http.StatusInternalServerError},
+		{`/\evil.com`, http.StatusInternalServerError},
+		{`\/evil.com`, http.StatusInternalServerError},
+		{`\\evil.com`, http.StatusInternalServerError},
+		{"javascript:alert(1)", http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.url, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/to?url="+tc.url, nil)
+			req.URL.RawQuery = "url=" + tc.url
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+			if tc.code == http.StatusFound {
+				assert.Equal(t, tc.url, w.Header().Get(lit.HeaderLocation))
+			}
+		})
+	}
+
+	t.Run("invalid code", func(t *testing.T) {
+		var err error
+		app.GET("/code", func(c *lit.Context) error {
+			err = c.Redirect("/", http.StatusOK)
+			return nil
+		})
+		makeRequest(app, "GET", "/code", nil, false)
+		assert.ErrorIs(t, err, lit.ErrInvalidRedirectCode)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/route", nil, false)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/users/7", w.Header().Get(lit.HeaderLocation))
+	})
+
+	t.Run("back", func(t *testing.T) {
+		back := func(referer string) string {
+			req := httptest.NewRequest("GET", "/back", nil)
+			req.Header.Set("Referer", referer)
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+			return w.Header().Get(lit.HeaderLocation)
+		}
+
+		assert.Equal(t, "http://example.com/list?page=2", back("http://example.com/list?page=2"))
+		assert.Equal(t, "/home", back("https://evil.com/"))
+		assert.Equal(t, "/home", back(""))
+	})
+}
@@ -0,0 +1,182 @@
+package lit
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Route is a route registered on an App. Mounted handlers are listed as
+// routes without a method.
+type Route struct {
+	app      *App
+	group    *Group
+	Method   string
+	Path     string
+	name     string
+	segments []segment
+	handlers []HandlerFunc // route handlers
+	chain    []HandlerFunc // app and group middleware followed by handlers
+	handler  string
+	meta     Map
+}
+
+// build composes the chain of the route from the current middleware.
+func (r *Route) build() {
+	r.chain = slices.Concat(r.app.middleware, r.group.chain(), r.handlers)
+}
+
+// RouteInfo describes a registered route, see App.Routes.
+type RouteInfo struct {
+	Method     string
+	Pattern    string
+	Name       string
+	Handler    string   // name of the final handler
+	Middleware []string // names of the handlers running before it
+	Meta       Map
+}
+
+// Name registers the route under name so URLs can be built for it with
+// App.URL and Context.URLFor. Names must be unique within the app.
+func (r *Route) Name(name string) *Route {
+	if _, ok := r.app.named[name]; ok {
+		panic(fmt.Sprintf("lit: route name %q already registered", name))
+	}
+	r.name = name
+	r.app.named[name] = r
+	return r
+}
+
+// Meta attaches metadata to the route, available through App.Routes.
+func (r *Route) Meta(key string, v any) *Route {
+	if r.meta == nil {
+		r.meta = make(Map)
+	}
+	r.meta[key] = v
+	return r
+}
+
+// Info describes the route.
+func (r *Route) Info() RouteInfo {
+	mw := make([]string, len(r.chain)-1)
+	for i, h := range r.chain[:len(r.chain)-1] {
+		mw[i] = funcName(h)
+	}
+
+	return RouteInfo{
+		Method:     r.Method,
+		Pattern:    r.Path,
+		Name:       r.name,
+		Handler:    r.handler,
+		Middleware: mw,
+		Meta:       maps.Clone(r.meta),
+	}
+}
+
+// URL builds the URL of the route. The params are key-value pairs: keys
+// naming a wildcard of the path replace it and the rest are appended as
+// query values, in order.
+//
+//	app.GET("/users/{id}", show).Name("user.show")
+//	app.URL("user.show", "id", 1, "tab", "posts") // /users/1?tab=posts
+func (r *Route) URL(params ...any) (string, error) {
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("route %s: odd number of params", r.Path)
+	}
+
+	keys := make([]string, 0, len(params)/2)
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		key, ok := params[i].(string)
+		if !ok {
+			return "", fmt.Errorf("route %s: param key %v is not a string", r.Path, params[i])
+		}
+		keys = append(keys, key)
+		values[key] = fmt.Sprint(params[i+1])
+	}
+
+	var b strings.Builder
+	used := make(map[string]bool)
+	for _, s := range r.segments {
+		p := s.param
+		if p == nil {
+			b.WriteString(s.text)
+			continue
+		}
+		if p.name == "$" {
+			continue
+		}
+
+		v, ok := values[p.name]
+		if !ok {
+			return "", fmt.Errorf("route %s: missing param %q", r.Path, p.name)
+		}
+		if p.re != nil && !p.re.MatchString(v) {
+			return "", fmt.Errorf("route %s: param %q does not match %s", r.Path, p.name, p.re)
+		}
+		used[p.name] = true
+
+		if p.rest {
+			segments := strings.Split(v, "/")
+			for k, s := range segments {
+				segments[k] = url.PathEscape(s)
+			}
+			b.WriteString(strings.Join(segments, "/"))
+		} else {
+			b.WriteString(url.PathEscape(v))
+		}
+	}
+
+	query := url.Values{}
+	for _, key := range keys {
+		if !used[key] {
+			query.Add(key, values[key])
+		}
+	}
+	if len(query) > 0 {
+		b.WriteString("?")
+		b.WriteString(query.Encode())
+	}
+
+	return b.String(), nil
+}
+
+// matches reports whether the path values of the request satisfy the
+// constraints of the route.
+func (r *Route) matches(req *http.Request) bool {
+	for _, s := range r.segments {
+		if p := s.param; p != nil && p.re != nil && !p.re.MatchString(req.PathValue(p.name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// URL builds the URL of the route registered under name. See Route.URL.
+func (a *App) URL(name string, params ...any) (string, error) {
+	r, ok := a.named[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrRouteNotFound, name)
+	}
+	return r.URL(params...)
+}
+
+// Routes lists the registered routes in registration order.
+func (a *App) Routes() []RouteInfo {
+	routes := make([]RouteInfo, len(a.routes))
+	for i, r := range a.routes {
+		routes[i] = r.Info()
+	}
+	return routes
+}
+
+// funcName returns the name of the function h, as reported by the runtime.
+func funcName(h HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+	return strings.TrimSuffix(name, "-fm")
+}
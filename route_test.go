@@ -0,0 +1,99 @@
+package lit_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedRoutes(t *testing.T) {
+	app := lit.New()
+	noop := func(c *lit.Context) error { return nil }
+
+	app.GET("/", noop).Name("home")
+	app.GET("/users/{id}", noop).Name("user.show")
+	app.GET("/files/{path...}", noop).Name("files")
+	app.Group("/api").Group("/v1").GET("/posts/{id}/{$}", noop).Name("post")
+
+	app.POST("/users", func(c *lit.Context) error {
+		loc, err := c.URLFor("user.show", "id", 42)
+		if err != nil {
+			return err
+		}
+		c.Header.Set(lit.HeaderLocation, loc)
+		return c.SendStatus(http.StatusCreated)
+	})
+
+	cases := []struct {
+		name   string
+		params []any
+		want   string
+	}{
+		{"home", nil, "/"},
+		{"user.show", []any{"id", 1}, "/users/1"},
+		{"user.show", []any{"id", "a b", "tab", "posts", "page", 2}, "/users/a%20b?page=2&tab=posts"},
+		{"files", []any{"path", "a/b c.txt"}, "/files/a/b%20c.txt"},
+		{"post", []any{"id", 7}, "/api/v1/posts/7/"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := app.URL(tc.name, tc.params...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, u)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := app.URL("nope")
+		assert.True(t, errors.Is(err, lit.ErrRouteNotFound))
+
+		_, err = app.URL("user.show")
+		assert.Error(t, err)
+
+		_, err = app.URL("user.show", "id")
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		assert.Panics(t, func() { app.GET("/home", noop).Name("home") })
+	})
+
+	t.Run("url for", func(t *testing.T) {
+		w := makeRequest(app, "POST", "/users", nil, false)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "/users/42", w.Header().Get(lit.HeaderLocation))
+	})
+}
+
+func TestRoutes(t *testing.T) {
+	app := lit.New()
+	app.Use(trace("app"))
+
+	list := func(c *lit.Context) error { return nil }
+	api := app.Group("/api", trace("api"))
+	api.GET("/users", list).Name("users").Meta("auth", true)
+	api.Mount("/admin", http.NotFoundHandler())
+
+	routes := app.Routes()
+	assert.Len(t, routes, 2)
+
+	r := routes[0]
+	assert.Equal(t, "GET", r.Method)
+	assert.Equal(t, "/api/users", r.Pattern)
+	assert.Equal(t, "users", r.Name)
+	assert.Equal(t, "github.com/jocades/lit_test.TestRoutes.func1", r.Handler)
+	assert.Len(t, r.Middleware, 2)
+	assert.Contains(t, r.Middleware[0], "lit_test.trace")
+	assert.Equal(t, lit.Map{"auth": true}, r.Meta)
+
+	m := routes[1]
+	assert.Empty(t, m.Method)
+	assert.Equal(t, "/api/admin/", m.Pattern)
+	assert.Equal(t, "http.HandlerFunc", m.Handler)
+}
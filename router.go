@@ -0,0 +1,196 @@
+package lit
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Router matches requests to the routes registered on an App. The default
+// router is backed by http.ServeMux, see NewTree for the alternative.
+type Router interface {
+	// Add registers the route for the method and path. The path follows the
+	// http.ServeMux pattern syntax, where a trailing slash matches the whole
+	// subtree, and an empty method matches any method.
+	Add(method, path string, r *Route)
+
+	// Find returns the route for the request, setting its path values. When
+	// no route matches but the path is registered under other methods, they
+	// are returned as allowed.
+	Find(r *http.Request) (route *Route, allowed []string)
+}
+
+// Option configures an App, see New.
+type Option func(*App)
+
+// WithRouter makes the app dispatch requests with r.
+func WithRouter(r Router) Option {
+	return func(a *App) {
+		a.router = r
+	}
+}
+
+// muxRouter is the default Router, backed by http.ServeMux.
+type muxRouter struct {
+	app *App
+	mux *http.ServeMux
+}
+
+func newMuxRouter(a *App) *muxRouter {
+	return &muxRouter{app: a, mux: http.NewServeMux()}
+}
+
+func (m *muxRouter) Add(method, path string, r *Route) {
+	pattern := path
+	if method != "" {
+		pattern = method + " " + path
+	}
+	m.mux.Handle(pattern, route{r})
+}
+
+func (m *muxRouter) Find(r *http.Request) (*Route, []string) {
+	res := &match{}
+	m.mux.ServeHTTP(res, r)
+
+	switch {
+	case res.route != nil:
+		return res.route, nil
+	case res.code == http.StatusMethodNotAllowed:
+		return nil, strings.Split(res.header.Get(HeaderAllow), ", ")
+	case res.code/100 == 3 && res.header.Get(HeaderLocation) != "":
+		// keep the redirects of the mux, like the one to the subtree root or
+		// to the cleaned path, whose code depends on the Go version
+		h := redirect(res.header.Get(HeaderLocation), res.code)
+		return &Route{app: m.app, chain: m.app.withMiddleware(h)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// route is registered on the mux in place of the Route so the router can
+// look it up without serving the request.
+type route struct {
+	r *Route
+}
+
+func (h route) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.(*match).route = h.r
+}
+
+// match is handed to the mux as the response writer to find out where a
+// request is routed without serving it. When no route matches, it records
+// the response the mux would have written instead.
+type match struct {
+	route  *Route
+	header http.Header
+	code   int
+}
+
+func (m *match) Header() http.Header {
+	if m.header == nil {
+		m.header = make(http.Header)
+	}
+	return m.header
+}
+
+func (m *match) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (m *match) WriteHeader(code int) {
+	m.code = code
+}
+
+// match finds the route and chain of handlers for the request, setting its
+// path values. The route is nil when none matches.
+func (a *App) match(r *http.Request) (*Route, []HandlerFunc) {
+	rt, allowed := a.router.Find(r)
+
+	if rt != nil {
+		if rt.matches(r) {
+			return rt, rt.chain
+		}
+		// the route of another method may still match the path
+		allowed = slices.DeleteFunc(a.methods(), func(m string) bool { return m == r.Method })
+	}
+
+	if allowed = a.matching(r, allowed); len(allowed) > 0 {
+		h := methodNotAllowed
+		if r.Method == http.MethodOptions {
+			h = options
+		}
+		return nil, append([]HandlerFunc{allow(allowOptions(allowed))}, a.withMiddleware(h)...)
+	}
+
+	return nil, a.withMiddleware(a.NotFoundHandler)
+}
+
+// methods returns every method the app has a route for, sorted.
+func (a *App) methods() []string {
+	var methods []string
+	for _, r := range a.routes {
+		if r.Method != "" && !slices.Contains(methods, r.Method) {
+			methods = append(methods, r.Method)
+		}
+	}
+	if slices.Contains(methods, http.MethodGet) && !slices.Contains(methods, http.MethodHead) {
+		methods = append(methods, http.MethodHead)
+	}
+	slices.Sort(methods)
+	return methods
+}
+
+// matching filters the allowed methods down to those whose route matches
+// the request, since the router ignores the constraints of path params.
+func (a *App) matching(r *http.Request, methods []string) []string {
+	var matching []string
+	for _, m := range methods {
+		r2 := *r
+		r2.Method = m
+		if rt, _ := a.router.Find(&r2); rt != nil && rt.matches(&r2) {
+			matching = append(matching, m)
+		}
+	}
+	return matching
+}
+
+// withMiddleware returns the chain of the app middleware followed by h.
+func (a *App) withMiddleware(h HandlerFunc) []HandlerFunc {
+	return append(slices.Clip(a.middleware), h)
+}
+
+// allowOptions adds OPTIONS to the allowed methods since every path answers
+// it automatically.
+func allowOptions(methods []string) string {
+	if !slices.Contains(methods, http.MethodOptions) {
+		methods = append(slices.Clip(methods), http.MethodOptions)
+		slices.Sort(methods)
+	}
+	return strings.Join(methods, ", ")
+}
+
+// allow sets the Allow header before the rest of the chain runs so
+// middleware, like CORS answering a preflight request, can read it.
+func allow(methods string) HandlerFunc {
+	return func(c *Context) error {
+		c.Header.Set(HeaderAllow, methods)
+		return c.Next()
+	}
+}
+
+// options answers OPTIONS requests for paths without an explicit OPTIONS
+// route.
+func options(c *Context) error {
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func methodNotAllowed(c *Context) error {
+	return ErrMethodNotAllowed
+}
+
+func redirect(url string, code int) HandlerFunc {
+	return func(c *Context) error {
+		http.Redirect(c.Res, c.Req, url, code)
+		return nil
+	}
+}
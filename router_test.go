@@ -0,0 +1,280 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/jocades/lit/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFound(t *testing.T) {
+	app := lit.New()
+	app.Use(trace("app"))
+
+	app.GET("/users/{id}", func(c *lit.Context) error {
+		return c.Text("user")
+	})
+
+	app.PUT("/users/{id}", func(c *lit.Context) error {
+		return c.Text("updated")
+	})
+
+	t.Run("unmatched path", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/nope", nil, false)
+		body, _ := decode[lit.Map](w.Body)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "Not Found", body["message"])
+		assert.Equal(t, "app", w.Header().Get("X-Trace"))
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := makeRequest(app, "DELETE", "/users/1", nil, false)
+		body, _ := decode[lit.Map](w.Body)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "Method Not Allowed", body["message"])
+		assert.Equal(t, "GET, HEAD, OPTIONS, PUT", w.Header().Get(lit.HeaderAllow))
+		assert.Equal(t, "app", w.Header().Get("X-Trace"))
+	})
+
+	t.Run("mux redirects", func(t *testing.T) {
+		app.GET("/static/", func(c *lit.Context) error {
+			return c.Text("static")
+		})
+
+		for path, location := range map[string]string{
+			"/static":   "/static/",
+			"//users/1": "/users/1",
+		} {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.URL.Path = path
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, req)
+
+			// the redirect code of http.ServeMux depends on the Go version
+			assert.Equal(t, 3, w.Code/100, path)
+			assert.Equal(t, location, w.Header().Get(lit.HeaderLocation))
+			assert.Equal(t, "app", w.Header().Get("X-Trace"))
+		}
+	})
+
+	t.Run("custom handler", func(t *testing.T) {
+		app.NotFoundHandler = func(c *lit.Context) error {
+			return c.Text("custom", http.StatusNotFound)
+		}
+		w := makeRequest(app, "GET", "/nope", nil, false)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "custom", w.Body.String())
+	})
+}
+
+func TestAutoMethods(t *testing.T) {
+	app := lit.New()
+	app.Use(func(c *lit.Context) error {
+		if c.Req.Method == http.MethodOptions {
+			c.Header.Set(lit.HeaderAccessControlAllowMethods, c.Header.Get(lit.HeaderAllow))
+		}
+		return c.Next()
+	})
+
+	app.GET("/users", func(c *lit.Context) error {
+		return c.JSON(lit.Map{"users": []string{"a", "b"}})
+	})
+
+	app.POST("/users", func(c *lit.Context) error {
+		return c.Text("created", http.StatusCreated)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		w := makeRequest(app, "OPTIONS", "/users", nil, false)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "GET, HEAD, OPTIONS, POST", w.Header().Get(lit.HeaderAllow))
+		assert.Equal(t, "GET, HEAD, OPTIONS, POST", w.Header().Get(lit.HeaderAccessControlAllowMethods))
+	})
+
+	t.Run("head", func(t *testing.T) {
+		w := makeRequest(app, "HEAD", "/users", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, lit.MIMEApplicationJSON, w.Header().Get(lit.HeaderContentType))
+		assert.Empty(t, w.Body.String())
+	})
+}
+
+func routers() map[string]func() *lit.App {
+	return map[string]func() *lit.App{
+		"mux":  func() *lit.App { return lit.New() },
+		"tree": func() *lit.App { return lit.New(lit.WithRouter(lit.NewTree())) },
+	}
+}
+
+func TestRouters(t *testing.T) {
+	echo := func(name string) lit.HandlerFunc {
+		return func(c *lit.Context) error {
+			return c.Text(name + " " + c.Param("id") + c.Param("path"))
+		}
+	}
+
+	cases := []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{"GET", "/", 200, "root "},
+		{"GET", "/users", 200, "users "},
+		{"GET", "/users/me", 200, "me "},
+		{"GET", "/users/42", 200, "user 42"},
+		{"DELETE", "/users/me", 200, "delete me"},
+		{"GET", "/users/42/posts", 200, "posts 42"},
+		{"GET", "/files/a/b.txt", 200, "files a/b.txt"},
+		{"GET", "/files/", 200, "files "},
+		{"GET", "/static/css/app.css", 200, "static "},
+		{"GET", "/static/", 200, "static "},
+		{"GET", "/dir/", 200, "dir "},
+		{"GET", "/mount/anything", 200, "/anything"},
+		{"POST", "/mount", 200, "/"},
+		{"GET", "/nope", 404, ""},
+		{"GET", "/users/42/nope", 404, ""},
+		{"GET", "/dir/x", 404, ""},
+		{"POST", "/users/42", 405, ""},
+	}
+
+	for name, newApp := range routers() {
+		app := newApp()
+		app.GET("/", echo("root"))
+		app.GET("/users", echo("users"))
+		app.GET("/users/me", echo("me"))
+		app.GET("/users/{id}", echo("user"))
+		app.PUT("/users/{id}", echo("update"))
+		app.DELETE("/users/{id}", echo("delete"))
+		app.GET("/users/{id}/posts", echo("posts"))
+		app.GET("/files/{path...}", echo("files"))
+		app.GET("/static/", echo("static"))
+		app.GET("/dir/{$}", echo("dir"))
+		app.Mount("/mount", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.URL.Path))
+		}))
+
+		for _, tc := range cases {
+			t.Run(name+" "+tc.method+" "+tc.path, func(t *testing.T) {
+				w := makeRequest(app, tc.method, tc.path, nil, false)
+
+				assert.Equal(t, tc.code, w.Code)
+				if tc.body != "" {
+					assert.Equal(t, tc.body, w.Body.String())
+				}
+			})
+		}
+
+		t.Run(name+" allow", func(t *testing.T) {
+			w := makeRequest(app, "POST", "/users/42", nil, false)
+			assert.Equal(t, "DELETE, GET, HEAD, OPTIONS, PUT", w.Header().Get(lit.HeaderAllow))
+		})
+
+		t.Run(name+" head", func(t *testing.T) {
+			w := makeRequest(app, "HEAD", "/users/42", nil, false)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Body.String())
+		})
+	}
+}
+
+func TestTreeFindAllocs(t *testing.T) {
+	tree := lit.NewTree()
+	tree.Add("GET", "/users/me", &lit.Route{})
+	tree.Add("GET", "/users/{id}", &lit.Route{})
+	req := httptest.NewRequest("GET", "/users/me", nil)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tree.Find(req)
+	})
+	assert.Zero(t, allocs)
+}
+
+func benchmarkRouter(b *testing.B, app *lit.App, path string) {
+	app.GET("/", func(c *lit.Context) error { return nil })
+	app.GET("/users", func(c *lit.Context) error { return nil })
+	app.GET("/users/{id}", func(c *lit.Context) error { return nil })
+	app.GET("/users/{id}/posts/{post}", func(c *lit.Context) error { return nil })
+	app.GET("/static/{path...}", func(c *lit.Context) error { return nil })
+
+	req := httptest.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		app.ServeHTTP(w, req)
+	}
+}
+
+func BenchmarkMuxStatic(b *testing.B) {
+	benchmarkRouter(b, lit.New(), "/users")
+}
+
+func BenchmarkTreeStatic(b *testing.B) {
+	benchmarkRouter(b, lit.New(lit.WithRouter(lit.NewTree())), "/users")
+}
+
+func BenchmarkMuxParams(b *testing.B) {
+	benchmarkRouter(b, lit.New(), "/users/42/posts/7")
+}
+
+func BenchmarkTreeParams(b *testing.B) {
+	benchmarkRouter(b, lit.New(lit.WithRouter(lit.NewTree())), "/users/42/posts/7")
+}
+
+func TestPre(t *testing.T) {
+	app := lit.New()
+	app.Pre(middleware.MethodOverride())
+	app.Pre(func(c *lit.Context) error {
+		c.Header.Add("X-Trace", "pre")
+		if p, ok := strings.CutPrefix(c.Req.URL.Path, "/old"); ok {
+			c.Req.URL.Path = "/new" + p
+		}
+		return c.Next()
+	})
+
+	app.GET("/new/{id}", func(c *lit.Context) error {
+		return c.Text("new " + c.Param("id"))
+	})
+
+	app.DELETE("/items/{id}", func(c *lit.Context) error {
+		return c.Text("deleted " + c.Param("id"))
+	})
+
+	// registered after the routes
+	app.Use(trace("app"))
+
+	t.Run("rewrite path", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/old/1", nil, false)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "new 1", w.Body.String())
+		assert.Equal(t, []string{"pre", "app"}, w.Header().Values("X-Trace"))
+	})
+
+	t.Run("method override", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/items/2", nil)
+		req.Header.Set(lit.HeaderXHTTPMethodOverride, "delete")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "deleted 2", w.Body.String())
+	})
+
+	t.Run("unmatched", func(t *testing.T) {
+		w := makeRequest(app, "GET", "/nope", nil, false)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, []string{"pre", "app"}, w.Header().Values("X-Trace"))
+	})
+}
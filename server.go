@@ -0,0 +1,176 @@
+package lit
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultShutdownTimeout is given to in-flight requests when the app shuts
+// down on a signal and App.ShutdownTimeout is not set.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// server is the running http.Server of an app.
+type server struct {
+	*http.Server
+	listener net.Listener
+	done     chan struct{} // closed once shut down
+	once     sync.Once
+}
+
+// Start listens on addr over App.ListenerNetwork and serves requests until
+// the app is shut down, see Listener.
+func (a *App) Start(addr string) error {
+	l, err := listen(a.ListenerNetwork, addr)
+	if err != nil {
+		return err
+	}
+	return a.Listener(l)
+}
+
+// StartTLS is like Start but serves HTTPS. The cert and key are either the
+// paths of PEM files, as a string, or their PEM contents, as a []byte.
+func (a *App) StartTLS(addr string, cert, key any) error {
+	certPEM, err := pemBytes(cert)
+	if err != nil {
+		return err
+	}
+	keyPEM, err := pemBytes(key)
+	if err != nil {
+		return err
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+
+	l, err := listen(a.ListenerNetwork, addr)
+	if err != nil {
+		return err
+	}
+	return a.Listener(tls.NewListener(l, &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}))
+}
+
+// Listener serves requests on l until the app is shut down, either with
+// Shutdown or on SIGINT or SIGTERM, which give in-flight requests
+// App.ShutdownTimeout to complete. It returns nil once they are drained. If
+// the app is already started, l is closed and ErrServerStarted returned.
+func (a *App) Listener(l net.Listener) error {
+	s := &server{
+		Server: &http.Server{
+			Handler:  a,
+			ErrorLog: slog.NewLogLogger(a.logger().Handler(), slog.LevelError),
+		},
+		listener: l,
+		done:     make(chan struct{}),
+	}
+
+	a.mu.Lock()
+	if a.server != nil {
+		a.mu.Unlock()
+		l.Close()
+		return ErrServerStarted
+	}
+	a.server = s
+	a.mu.Unlock()
+
+	defer func() {
+		a.mu.Lock()
+		a.server = nil
+		a.mu.Unlock()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a.started(l.Addr())
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.Serve(l)
+	}()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		// Serve returns as soon as Shutdown is called, wait for the
+		// in-flight requests
+		<-s.done
+		return nil
+	case <-ctx.Done():
+		stop()
+		timeout := a.ShutdownTimeout
+		if timeout == 0 {
+			timeout = DefaultShutdownTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return a.Shutdown(ctx)
+	}
+}
+
+// Shutdown stops the app from accepting connections and waits for the
+// in-flight requests to complete, or for ctx to be done, before running the
+// OnShutdown hooks. It does nothing if the app is not started.
+func (a *App) Shutdown(ctx context.Context) error {
+	a.mu.Lock()
+	s := a.server
+	a.mu.Unlock()
+	if s == nil {
+		return nil
+	}
+
+	err := s.Shutdown(ctx)
+	s.once.Do(func() {
+		err = errors.Join(err, a.stopped(ctx))
+		close(s.done)
+	})
+	return err
+}
+
+// Addr returns the address the app is listening on, or nil if it is not
+// started.
+func (a *App) Addr() net.Addr {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.server == nil {
+		return nil
+	}
+	return a.server.listener.Addr()
+}
+
+func listen(network, addr string) (net.Listener, error) {
+	switch network {
+	case "":
+		network = "tcp"
+	case "tcp", "tcp4", "tcp6", "unix":
+	default:
+		return nil, ErrInvalidListenerNetwork
+	}
+	return net.Listen(network, addr)
+}
+
+// pemBytes reads the PEM file at v, a path, or returns v as is for a []byte.
+func pemBytes(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return os.ReadFile(v)
+	case []byte:
+		return v, nil
+	default:
+		return nil, ErrInvalidCertOrKeyType
+	}
+}
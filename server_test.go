@@ -0,0 +1,197 @@
+package lit_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListener(t *testing.T) {
+	app := lit.New()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	app.GET("/slow", func(c *lit.Context) error {
+		close(started)
+		<-release
+		return c.Text("done")
+	})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	served := make(chan error, 1)
+	go func() { served <- app.Listener(l) }()
+
+	res := make(chan string, 1)
+	go func() {
+		r, err := http.Get("http://" + l.Addr().String() + "/slow")
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		res <- string(b)
+	}()
+
+	<-started
+	assert.Equal(t, l.Addr(), app.Addr())
+
+	l2, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, app.Listener(l2), lit.ErrServerStarted)
+	l2, err = net.Listen("tcp", l2.Addr().String())
+	if assert.NoError(t, err) {
+		l2.Close()
+	}
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- app.Shutdown(context.Background()) }()
+
+	select {
+	case <-served:
+		t.Fatal("listener returned before draining requests")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.Equal(t, "done", <-res)
+	assert.NoError(t, <-shutdown)
+	assert.NoError(t, <-served)
+	assert.Nil(t, app.Addr())
+}
+
+func TestStart(t *testing.T) {
+	t.Run("invalid network", func(t *testing.T) {
+		app := lit.New()
+		app.ListenerNetwork = "udp"
+		assert.ErrorIs(t, app.Start(":0"), lit.ErrInvalidListenerNetwork)
+	})
+
+	t.Run("unix", func(t *testing.T) {
+		app := lit.New()
+		app.ListenerNetwork = "unix"
+		app.GET("/", func(c *lit.Context) error { return c.Text("unix") })
+
+		sock := filepath.Join(t.TempDir(), "lit.sock")
+		served := make(chan error, 1)
+		go func() { served <- app.Start(sock) }()
+		waitStarted(t, app)
+
+		client := &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", sock)
+			},
+		}}
+		assert.Equal(t, "unix", get(t, client, "http://lit/"))
+
+		assert.NoError(t, app.Shutdown(context.Background()))
+		assert.NoError(t, <-served)
+	})
+
+	t.Run("started twice", func(t *testing.T) {
+		app := lit.New()
+		served := make(chan error, 1)
+		go func() { served <- app.Start("127.0.0.1:0") }()
+		waitStarted(t, app)
+
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		addr := l.Addr().String()
+		l.Close()
+
+		assert.ErrorIs(t, app.Start(addr), lit.ErrServerStarted)
+		l, err = net.Listen("tcp", addr)
+		if assert.NoError(t, err) {
+			l.Close()
+		}
+
+		assert.NoError(t, app.Shutdown(context.Background()))
+		assert.NoError(t, <-served)
+	})
+
+	t.Run("shutdown before start", func(t *testing.T) {
+		assert.NoError(t, lit.New().Shutdown(context.Background()))
+	})
+}
+
+func TestStartTLS(t *testing.T) {
+	cert, key := selfSigned(t)
+
+	t.Run("invalid type", func(t *testing.T) {
+		app := lit.New()
+		assert.ErrorIs(t, app.StartTLS(":0", 1, key), lit.ErrInvalidCertOrKeyType)
+		assert.ErrorIs(t, app.StartTLS(":0", cert, nil), lit.ErrInvalidCertOrKeyType)
+	})
+
+	t.Run("pem", func(t *testing.T) {
+		app := lit.New()
+		app.GET("/", func(c *lit.Context) error { return c.Text(c.Req.Proto) })
+
+		served := make(chan error, 1)
+		go func() { served <- app.StartTLS("127.0.0.1:0", cert, key) }()
+		waitStarted(t, app)
+
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		}}
+		assert.Equal(t, "HTTP/2.0", get(t, client, "https://"+app.Addr().String()+"/"))
+
+		assert.NoError(t, app.Shutdown(context.Background()))
+		assert.NoError(t, <-served)
+	})
+}
+
+func waitStarted(t *testing.T, app *lit.App) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		return app.Addr() != nil
+	}, time.Second, time.Millisecond)
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	res, err := client.Get(url)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	return string(b)
+}
+
+// selfSigned returns the PEM encoded certificate and key for 127.0.0.1.
+func selfSigned(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+	key, err := x509.MarshalECPrivateKey(priv)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})
+}
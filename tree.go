@@ -0,0 +1,234 @@
+package lit
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Tree is a radix tree Router. It supports the same patterns as the default
+// router: static segments, {name} wildcards, {name...} wildcards matching the
+// rest of the path, {$} and trailing slashes matching a subtree. Static
+// segments take precedence over wildcards, and matching static routes does
+// not allocate.
+//
+// Paths are matched in their decoded form.
+type Tree struct {
+	root node
+}
+
+// NewTree returns an empty Tree, to be used with WithRouter.
+func NewTree() *Tree {
+	return &Tree{}
+}
+
+type node struct {
+	prefix   string  // static text matched by the node
+	children []*node // static children, with distinct first bytes
+	param    *node   // {name}
+	rest     *node   // {name...} or a trailing slash
+	routes   []entry
+}
+
+// entry is a route registered at a node.
+type entry struct {
+	method string
+	route  *Route
+	names  []string // names of the wildcards leading to the node, in order
+}
+
+func (t *Tree) Add(method, path string, r *Route) {
+	segments, err := parsePath(path)
+	if err != nil {
+		panic("lit: " + err.Error())
+	}
+
+	n := &t.root
+	var names []string
+	for i, s := range segments {
+		p := s.param
+		switch {
+		case p == nil:
+			text := s.text
+			subtree := i == len(segments)-1 && strings.HasSuffix(text, "/")
+			n = n.insert(text)
+			if subtree {
+				if n.rest == nil {
+					n.rest = &node{}
+				}
+				n = n.rest
+				names = append(names, "")
+			}
+		case p.name == "$":
+		case p.rest:
+			if n.rest == nil {
+				n.rest = &node{}
+			}
+			n = n.rest
+			names = append(names, p.name)
+		default:
+			if n.param == nil {
+				n.param = &node{}
+			}
+			n = n.param
+			names = append(names, p.name)
+		}
+	}
+
+	for _, e := range n.routes {
+		if e.method == method {
+			panic(fmt.Sprintf("lit: route %s %s already registered", method, path))
+		}
+	}
+	n.routes = append(n.routes, entry{method: method, route: r, names: names})
+}
+
+// insert returns the node matching the static text s below n, splitting
+// nodes as needed.
+func (n *node) insert(s string) *node {
+	for s != "" {
+		c := n.child(s[0])
+		if c == nil {
+			c = &node{prefix: s}
+			n.children = append(n.children, c)
+			return c
+		}
+
+		l := 0
+		for l < len(s) && l < len(c.prefix) && s[l] == c.prefix[l] {
+			l++
+		}
+		if l < len(c.prefix) {
+			split := *c
+			split.prefix = c.prefix[l:]
+			*c = node{prefix: c.prefix[:l], children: []*node{&split}}
+		}
+
+		n, s = c, s[l:]
+	}
+	return n
+}
+
+func (n *node) child(b byte) *node {
+	for _, c := range n.children {
+		if c.prefix[0] == b {
+			return c
+		}
+	}
+	return nil
+}
+
+func (t *Tree) Find(r *http.Request) (*Route, []string) {
+	path := r.URL.Path
+	if e := t.root.find(path, 0, r.Method, r); e != nil {
+		return e.route, nil
+	}
+
+	var allowed []string
+	t.root.allowed(path, &allowed)
+	if len(allowed) == 0 {
+		return nil, nil
+	}
+	if slices.Contains(allowed, http.MethodGet) {
+		allowed = append(allowed, http.MethodHead)
+	}
+	slices.Sort(allowed)
+	return nil, slices.Compact(allowed)
+}
+
+// find returns the entry for the method matching the remaining path below n,
+// where k wildcards have been matched so far. Wildcard values are set on the
+// request while unwinding, once the entry and so their names are known.
+func (n *node) find(path string, k int, method string, r *http.Request) *entry {
+	if path == "" {
+		if e := n.entry(method); e != nil {
+			return e
+		}
+	} else {
+		if c := n.child(path[0]); c != nil && strings.HasPrefix(path, c.prefix) {
+			if e := c.find(path[len(c.prefix):], k, method, r); e != nil {
+				return e
+			}
+		}
+
+		if c := n.param; c != nil {
+			i := strings.IndexByte(path, '/')
+			if i < 0 {
+				i = len(path)
+			}
+			if i > 0 {
+				if e := c.find(path[i:], k+1, method, r); e != nil {
+					setPathValue(r, e.names[k], path[:i])
+					return e
+				}
+			}
+		}
+	}
+
+	if c := n.rest; c != nil {
+		if e := c.entry(method); e != nil {
+			setPathValue(r, e.names[k], path)
+			return e
+		}
+	}
+	return nil
+}
+
+// entry returns the entry serving the method: an exact match, the GET entry
+// for HEAD or an entry for any method.
+func (n *node) entry(method string) *entry {
+	var get, wild *entry
+	for i := range n.routes {
+		e := &n.routes[i]
+		switch e.method {
+		case method:
+			return e
+		case http.MethodGet:
+			get = e
+		case "":
+			wild = e
+		}
+	}
+	if get != nil && method == http.MethodHead {
+		return get
+	}
+	return wild
+}
+
+// allowed collects the methods of every entry matching the remaining path
+// below n.
+func (n *node) allowed(path string, methods *[]string) {
+	add := func(n *node) {
+		for _, e := range n.routes {
+			*methods = append(*methods, e.method)
+		}
+	}
+
+	if path == "" {
+		add(n)
+	} else {
+		if c := n.child(path[0]); c != nil && strings.HasPrefix(path, c.prefix) {
+			c.allowed(path[len(c.prefix):], methods)
+		}
+		if c := n.param; c != nil {
+			i := strings.IndexByte(path, '/')
+			if i < 0 {
+				i = len(path)
+			}
+			if i > 0 {
+				c.allowed(path[i:], methods)
+			}
+		}
+	}
+
+	if n.rest != nil {
+		add(n.rest)
+	}
+}
+
+func setPathValue(r *http.Request, name, value string) {
+	if name != "" {
+		r.SetPathValue(name, value)
+	}
+}
@@ -0,0 +1,233 @@
+package lit
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Validator validates the values bound from requests, see App.Validator.
+type Validator interface {
+	Validate(v any) error
+}
+
+// ValidationError describes a field failing a validation rule.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationErrors lists every failing field, as returned by TagValidator.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate validates v with the app Validator. Failures result in
+// ErrUnprocessableEntity, listing each ValidationError as its message.
+func (c *Context) Validate(v any) error {
+	if c.app.Validator == nil {
+		return ErrValidatorNotRegistered
+	}
+
+	err := c.app.Validator.Validate(v)
+	if err == nil {
+		return nil
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return err
+	}
+
+	e := ErrUnprocessableEntity.WithInternal(err)
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		return e.WithMessage(errs)
+	}
+	return e.WithMessage(err.Error())
+}
+
+// TagValidator is the built-in Validator, checking the rules listed in the
+// validate tag of struct fields:
+//
+//	type User struct {
+//		Name  string `json:"name" validate:"required,min=3"`
+//		Email string `json:"email" validate:"required,email"`
+//		Role  string `json:"role" validate:"oneof=admin user"`
+//	}
+//
+// The rules are required, min, max and len, which compare numbers by value
+// and strings, slices and maps by length, email and oneof. Rules other than
+// required are skipped for zero values. Nested structs are validated too and
+// fields are named after their json tag.
+//
+// A tag which cannot be checked, like an unknown rule, results in an
+// ErrInternalServerError wrapping ErrInvalidValidationRule.
+type TagValidator struct{}
+
+func (TagValidator) Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs ValidationErrors
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return ErrInternalServerError.WithInternal(err)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateStruct appends the failing fields of v to errs. The error reports
+// an invalid tag rather than an invalid value.
+func validateStruct(v reflect.Value, prefix string, errs *ValidationErrors) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		f, fv := t.Field(i), v.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := prefix + fieldName(f)
+		if tag := f.Tag.Get("validate"); tag != "" && tag != "-" {
+			if err := validateField(fv, name, tag, errs); err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+		}
+
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && fv.Type().PkgPath() != "time" {
+			nested := name + "."
+			if f.Anonymous {
+				nested = prefix
+			}
+			if err := validateStruct(fv, nested, errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldName names the field after its json tag, if any.
+func fieldName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+func validateField(v reflect.Value, name, tag string, errs *ValidationErrors) error {
+	rules := strings.Split(tag, ",")
+	for _, rule := range rules {
+		if key, _, _ := strings.Cut(rule, "="); !slices.Contains(knownRules, key) {
+			return fmt.Errorf("%w: unknown rule %q", ErrInvalidValidationRule, key)
+		}
+	}
+
+	if v.IsZero() {
+		if slices.Contains(rules, "required") {
+			*errs = append(*errs, ValidationError{Field: name, Rule: "required", Message: "is required"})
+		}
+		return nil
+	}
+
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	for _, rule := range rules {
+		key, arg, _ := strings.Cut(rule, "=")
+		msg, err := check(v, key, arg)
+		if err != nil {
+			return err
+		}
+		if msg != "" {
+			*errs = append(*errs, ValidationError{Field: name, Rule: rule, Message: msg})
+		}
+	}
+	return nil
+}
+
+var knownRules = []string{"required", "min", "max", "len", "email", "oneof"}
+
+// check applies the rule to v, returning a message when it fails.
+func check(v reflect.Value, rule, arg string) (string, error) {
+	switch rule {
+	case "min", "max", "len":
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid argument %s=%s", ErrInvalidValidationRule, rule, arg)
+		}
+		return checkSize(v, rule, n)
+	case "email":
+		if v.Kind() != reflect.String {
+			return "must be a valid email address", nil
+		}
+		if addr, err := mail.ParseAddress(v.String()); err != nil || addr.Address != v.String() {
+			return "must be a valid email address", nil
+		}
+	case "oneof":
+		options := strings.Fields(arg)
+		if !slices.Contains(options, fmt.Sprint(v.Interface())) {
+			return "must be one of " + strings.Join(options, ", "), nil
+		}
+	}
+	return "", nil
+}
+
+func checkSize(v reflect.Value, rule string, n float64) (string, error) {
+	var size float64
+	unit := ""
+	switch v.Kind() {
+	case reflect.String:
+		size, unit = float64(len([]rune(v.String()))), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		size, unit = float64(v.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = v.Float()
+	default:
+		return "", fmt.Errorf("%w: %s does not apply to %s", ErrInvalidValidationRule, rule, v.Kind())
+	}
+
+	bound := strconv.FormatFloat(n, 'f', -1, 64) + unit
+	switch {
+	case rule == "min" && size < n:
+		return "must be at least " + bound, nil
+	case rule == "max" && size > n:
+		return "must be at most " + bound, nil
+	case rule == "len" && size != n:
+		return "must be exactly " + bound, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,129 @@
+package lit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jocades/lit"
+	"github.com/stretchr/testify/assert"
+)
+
+type Address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type SignUp struct {
+	Name    string   `json:"name" validate:"required,min=3"`
+	Email   string   `json:"email" validate:"required,email"`
+	Role    string   `json:"role" validate:"oneof=admin user"`
+	Age     int      `json:"age" validate:"min=18,max=130"`
+	Tags    []string `json:"tags" validate:"max=2"`
+	Address *Address `json:"address"`
+}
+
+func TestValidate(t *testing.T) {
+	app := lit.New()
+
+	app.POST("/signup", func(c *lit.Context) error {
+		v, err := lit.Bind[SignUp](c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(v, http.StatusCreated)
+	})
+
+	app.POST("/manual", func(c *lit.Context) error {
+		return c.Validate(&SignUp{})
+	})
+
+	send := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set(lit.HeaderContentType, lit.MIMEApplicationJSON)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("not registered", func(t *testing.T) {
+		w := send("/manual", "{}")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	app.Validator = lit.TagValidator{}
+
+	t.Run("valid", func(t *testing.T) {
+		w := send("/signup", `{"name":"jo jo","email":"jo@x.io","role":"user","age":30}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		w := send("/signup", `{"name":"jo","email":"jo@","role":"root","age":12,"tags":["a","b","c"],"address":{}}`)
+		body, _ := decode[map[string][]lit.ValidationError](w.Body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, []lit.ValidationError{
+			{Field: "name", Rule: "min=3", Message: "must be at least 3 characters"},
+			{Field: "email", Rule: "email", Message: "must be a valid email address"},
+			{Field: "role", Rule: "oneof=admin user", Message: "must be one of admin, user"},
+			{Field: "age", Rule: "min=18", Message: "must be at least 18"},
+			{Field: "tags", Rule: "max=2", Message: "must be at most 2 items"},
+			{Field: "address.city", Rule: "required", Message: "is required"},
+		}, body["message"])
+	})
+
+	t.Run("required", func(t *testing.T) {
+		w := send("/manual", "{}")
+		body, _ := decode[map[string][]lit.ValidationError](w.Body)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Len(t, body["message"], 2)
+	})
+}
+
+func TestValidatorNotRegistered(t *testing.T) {
+	app := lit.New()
+	var err error
+	app.GET("/", func(c *lit.Context) error {
+		err = c.Validate(struct{}{})
+		return nil
+	})
+
+	makeRequest(app, "GET", "/", nil, false)
+	assert.ErrorIs(t, err, lit.ErrValidatorNotRegistered)
+}
+
+func TestTagValidatorInvalidRule(t *testing.T) {
+	cases := []struct {
+		name string
+		v    any
+	}{
+		{"unknown rule", &struct {
+			Name string `validate:"requried"`
+		}{}},
+		{"invalid argument", &struct {
+			Name string `validate:"min=three"`
+		}{Name: "jo"}},
+		{"unsupported kind", &struct {
+			Ok bool `validate:"min=1"`
+		}{Ok: true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := lit.New()
+			app.Validator = lit.TagValidator{}
+
+			var err error
+			app.GET("/", func(c *lit.Context) error {
+				err = c.Validate(tc.v)
+				return err
+			})
+
+			w := makeRequest(app, "GET", "/", nil, false)
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.ErrorIs(t, err, lit.ErrInvalidValidationRule)
+		})
+	}
+}